@@ -18,6 +18,8 @@ package app
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"net"
 	"os"
@@ -28,14 +30,13 @@ import (
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/goadesign/goa/grpc/middleware"
-	ctclient "github.com/google/certificate-transparency-go/client"
 	grpcmw "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_zap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
 	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sigstore/fulcio/pkg/audit"
 	"github.com/sigstore/fulcio/pkg/ca"
-	"github.com/sigstore/fulcio/pkg/config"
 	gw "github.com/sigstore/fulcio/pkg/generated/protobuf"
 	gw_legacy "github.com/sigstore/fulcio/pkg/generated/protobuf/legacy"
 	"github.com/sigstore/fulcio/pkg/identity"
@@ -57,15 +58,17 @@ type grpcServer struct {
 	grpcServerEndpoint string
 	caService          gw.CAServer
 	tlsCertWatcher     *fsnotify.Watcher
+	rateLimiter        *server.RateLimiter
 }
 
-func PassFulcioConfigThruContext(cfg *config.FulcioConfig) grpc.UnaryServerInterceptor {
+// PassFulcioConfigThruContext adds the current FulcioConfig, and the issuer
+// pool built from it, to the context of each request. The configuration may be
+// reloaded at any time, so handlers must read it from the context rather than
+// from cfg in order to see a single consistent version.
+func PassFulcioConfigThruContext(cfg *server.ConfigReloader) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		// For each request, infuse context with our snapshot of the FulcioConfig.
-		// TODO(mattmoor): Consider periodically (every minute?) refreshing the ConfigMap
-		// from disk, so that we don't need to cycle pods to pick up config updates.
-		// Alternately we could take advantage of Knative's configmap watcher.
-		ctx = config.With(ctx, cfg)
+		ctx = cfg.With(ctx)
 		ctx, cancel := context.WithCancel(ctx)
 		defer cancel()
 
@@ -146,25 +149,52 @@ func (c *cachedTLSCert) UpdateCertificate() error {
 	return nil
 }
 
-func (c *cachedTLSCert) GRPCCreds() grpc.ServerOption {
-	return grpc.Creds(credentials.NewTLS(&tls.Config{
+// GRPCCreds returns the TLS credentials of the server. If clientCAs is not
+// nil, client certificates issued by them are verified when presented.
+func (c *cachedTLSCert) GRPCCreds(clientCAs *x509.CertPool) grpc.ServerOption {
+	tlsConfig := &tls.Config{
 		GetCertificate: func(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
 			return c.GetCertificate(), nil
 		},
 		MinVersion: tls.VersionTLS13,
-	}))
+	}
+	if clientCAs != nil {
+		// Clients authenticating with OIDC tokens don't present a certificate
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+		tlsConfig.ClientCAs = clientCAs
+	}
+	return grpc.Creds(credentials.NewTLS(tlsConfig))
 }
 
-func createGRPCServer(cfg *config.FulcioConfig, ctClient *ctclient.LogClient, baseca ca.CertificateAuthority, ip identity.IssuerPool) (*grpcServer, error) {
-	logger, opts := log.SetupGRPCLogging()
+func createGRPCServer(cfg *server.ConfigReloader, baseca ca.CertificateAuthority, ip identity.IssuerPool, auditLogger *audit.Logger, opts ...server.GRPCCAServerOption) (*grpcServer, error) {
+	logger, logOpts := log.SetupGRPCLogging()
+
+	// Client certificate issuers are not reloaded, since the TLS configuration
+	// is fixed when the server starts
+	var clientCAs *x509.CertPool
+	rateLimitOpts := []server.RateLimiterOption{server.WithRateLimiterAuditLogger(auditLogger)}
+	if cfg := cfg.Config(); cfg != nil && len(cfg.ClientCertificateIssuers) > 0 {
+		if !viper.IsSet("grpc-tls-certificate") || !viper.IsSet("grpc-tls-key") {
+			return nil, errors.New("client certificate issuers require grpc-tls-certificate and grpc-tls-key to be set")
+		}
+		verifier, err := server.NewClientCertificateVerifier(cfg)
+		if err != nil {
+			return nil, err
+		}
+		clientCAs = verifier.ClientCAs()
+		opts = append(opts, server.WithClientCertificateVerifier(verifier))
+		rateLimitOpts = append(rateLimitOpts, server.WithRateLimiterClientCertificates(verifier))
+	}
+	rateLimiter := server.NewRateLimiter(ip, rateLimitOpts...)
 
 	serverOpts := []grpc.ServerOption{
 		grpc.UnaryInterceptor(
 			grpcmw.ChainUnaryServer(
 				grpc_recovery.UnaryServerInterceptor(grpc_recovery.WithRecoveryHandlerContext(panicRecoveryHandler)), // recovers from per-transaction panics elegantly, so put it first
 				middleware.UnaryRequestID(middleware.UseXRequestIDMetadataOption(true), middleware.XRequestMetadataLimitOption(128)),
-				grpc_zap.UnaryServerInterceptor(logger, opts...),
+				grpc_zap.UnaryServerInterceptor(logger, logOpts...),
 				PassFulcioConfigThruContext(cfg),
+				rateLimiter.UnaryServerInterceptor(), // requires the config from the context
 				grpc_prometheus.UnaryServerInterceptor,
 			)),
 		grpc.KeepaliveParams(keepalive.ServerParameters{
@@ -181,19 +211,19 @@ func createGRPCServer(cfg *config.FulcioConfig, ctClient *ctclient.LogClient, ba
 		}
 
 		tlsCertWatcher = cachedTLSCert.Watcher
-		serverOpts = append(serverOpts, cachedTLSCert.GRPCCreds())
+		serverOpts = append(serverOpts, cachedTLSCert.GRPCCreds(clientCAs))
 	}
 
 	myServer := grpc.NewServer(serverOpts...)
 
-	grpcCAServer := server.NewGRPCCAServer(ctClient, baseca, ip)
+	grpcCAServer := server.NewGRPCCAServer(baseca, ip, opts...)
 
 	health.RegisterHealthServer(myServer, grpcCAServer)
 	// Register your gRPC service implementations.
 	gw.RegisterCAServer(myServer, grpcCAServer)
 
 	grpcServerEndpoint := fmt.Sprintf("%s:%s", viper.GetString("grpc-host"), viper.GetString("grpc-port"))
-	return &grpcServer{myServer, grpcServerEndpoint, grpcCAServer, tlsCertWatcher}, nil
+	return &grpcServer{myServer, grpcServerEndpoint, grpcCAServer, tlsCertWatcher, rateLimiter}, nil
 }
 
 func (g *grpcServer) setupPrometheus(reg *prometheus.Registry) {
@@ -269,7 +299,10 @@ func (g *grpcServer) ExposesGRPCTLS() bool {
 	return viper.IsSet("grpc-tls-certificate") && viper.IsSet("grpc-tls-key")
 }
 
-func createLegacyGRPCServer(cfg *config.FulcioConfig, unixDomainSocket string, v2Server gw.CAServer) (*grpcServer, error) {
+// createLegacyGRPCServer creates a server for the legacy API that forwards
+// requests to v2Server. rateLimiter should be the one used by the server for the
+// v2 API, so that both APIs share the same limits.
+func createLegacyGRPCServer(cfg *server.ConfigReloader, unixDomainSocket string, v2Server gw.CAServer, rateLimiter *server.RateLimiter) (*grpcServer, error) {
 	logger, opts := log.SetupGRPCLogging()
 
 	myServer := grpc.NewServer(grpc.UnaryInterceptor(
@@ -278,6 +311,7 @@ func createLegacyGRPCServer(cfg *config.FulcioConfig, unixDomainSocket string, v
 			middleware.UnaryRequestID(middleware.UseXRequestIDMetadataOption(true), middleware.XRequestMetadataLimitOption(128)),
 			grpc_zap.UnaryServerInterceptor(logger, opts...),
 			PassFulcioConfigThruContext(cfg),
+			rateLimiter.UnaryServerInterceptor(), // requires the config from the context
 			grpc_prometheus.UnaryServerInterceptor,
 		)),
 		grpc.MaxRecvMsgSize(int(maxMsgSize)))
@@ -287,7 +321,7 @@ func createLegacyGRPCServer(cfg *config.FulcioConfig, unixDomainSocket string, v
 	// Register your gRPC service implementations.
 	gw_legacy.RegisterCAServer(myServer, legacyGRPCCAServer)
 
-	return &grpcServer{myServer, unixDomainSocket, v2Server, nil}, nil
+	return &grpcServer{myServer, unixDomainSocket, v2Server, nil, rateLimiter}, nil
 }
 
 func panicRecoveryHandler(ctx context.Context, p interface{}) error {
@@ -71,6 +71,7 @@ func createHTTPServer(ctx context.Context, serverEndpoint string, grpcServer, le
 
 	mux := runtime.NewServeMux(runtime.WithMetadata(extractOIDCTokenFromAuthHeader),
 		runtime.WithForwardResponseOption(setResponseCodeModifier),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
 		runtime.WithHealthzEndpoint(health.NewHealthClient(cc)))
 
 	if err := gw.RegisterCAHandlerFromEndpoint(ctx, mux, grpcServer.grpcServerEndpoint, opts); err != nil {
@@ -137,6 +138,17 @@ func (h httpServer) startListener(wg *sync.WaitGroup) {
 	}()
 }
 
+// outgoingHeaderMatcher forwards the retry-after hint of rate limited requests
+// as the standard Retry-After header. The status code of those requests is set
+// to 429 by the gateway's default mapping of codes.ResourceExhausted.
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if key == server.RetryAfterMetadataKey {
+		return "Retry-After", true
+	}
+	// the default behavior of the gateway
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
 func setResponseCodeModifier(ctx context.Context, w http.ResponseWriter, _ proto.Message) error {
 	md, ok := runtime.ServerMetadataFromContext(ctx)
 	if !ok {
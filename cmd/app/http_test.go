@@ -19,6 +19,7 @@ import (
 	"context"
 	"crypto"
 	"crypto/x509"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
@@ -32,6 +33,7 @@ import (
 
 	"github.com/sigstore/fulcio/pkg/ca"
 	"github.com/sigstore/fulcio/pkg/identity"
+	"github.com/sigstore/fulcio/pkg/server"
 	"github.com/spf13/viper"
 
 	"google.golang.org/grpc"
@@ -47,7 +49,7 @@ func setupHTTPServer(t *testing.T) (httpServer, string) {
 
 	viper.Set("grpc-host", "")
 	viper.Set("grpc-port", 0)
-	grpcServer, err := createGRPCServer(nil, nil, &TrivialCertificateAuthority{}, nil)
+	grpcServer, err := createGRPCServer(nil, &TrivialCertificateAuthority{}, nil, nil)
 	if err != nil {
 		t.Error(err)
 	}
@@ -93,7 +95,7 @@ func setupHTTPServerWithGRPCTLS(t *testing.T) (httpServer, string) {
 
 	viper.Set("grpc-host", "")
 	viper.Set("grpc-port", 0)
-	grpcServer, err := createGRPCServer(nil, nil, &TrivialCertificateAuthority{}, nil)
+	grpcServer, err := createGRPCServer(nil, &TrivialCertificateAuthority{}, nil, nil)
 	if err != nil {
 		t.Error(err)
 	}
@@ -109,7 +111,7 @@ func setupHTTPServerWithGRPCTLS(t *testing.T) (httpServer, string) {
 	if err != nil {
 		t.Error(err)
 	}
-	legacyGRPCServer, err := createLegacyGRPCServer(nil, LegacyUnixDomainSocket, grpcServer.caService)
+	legacyGRPCServer, err := createLegacyGRPCServer(nil, LegacyUnixDomainSocket, grpcServer.caService, grpcServer.rateLimiter)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -165,6 +167,50 @@ func TestHTTPDoesntLeakGRPCHeaders(t *testing.T) {
 	}
 }
 
+func TestOutgoingHeaderMatcher(t *testing.T) {
+	if got, ok := outgoingHeaderMatcher(server.RetryAfterMetadataKey); !ok || got != "Retry-After" {
+		t.Errorf("outgoingHeaderMatcher(%q) = %q, %v", server.RetryAfterMetadataKey, got, ok)
+	}
+	if got, ok := outgoingHeaderMatcher("foo"); !ok || got != "Grpc-Metadata-foo" {
+		t.Errorf("outgoingHeaderMatcher(%q) = %q, %v", "foo", got, ok)
+	}
+}
+
+func TestHTTPErrorDetails(t *testing.T) {
+	httpServer, host := setupHTTPServer(t)
+	defer httpServer.Close()
+
+	body := strings.NewReader(`{"credentials": {"oidcIdentityToken": "not-a-jwt"}}`)
+	resp, err := http.Post(host+"/api/v2/signingCert", "application/json", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("got status %d, wanted %d", resp.StatusCode, http.StatusBadRequest)
+	}
+
+	var errResp struct {
+		Details []struct {
+			Type   string `json:"@type"`
+			Reason string `json:"reason"`
+			Domain string `json:"domain"`
+		} `json:"details"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
+		t.Fatal(err)
+	}
+	for _, detail := range errResp.Details {
+		if detail.Type == "type.googleapis.com/google.rpc.ErrorInfo" {
+			if detail.Reason != server.ReasonMalformedToken || detail.Domain != server.ErrorDomain {
+				t.Errorf("got reason %q in domain %q", detail.Reason, detail.Domain)
+			}
+			return
+		}
+	}
+	t.Errorf("no ErrorInfo in error details %+v", errResp.Details)
+}
+
 func TestIssue1267(t *testing.T) {
 	httpServer, host := setupHTTPServerWithGRPCTLS(t)
 	defer httpServer.Close()
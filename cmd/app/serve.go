@@ -44,6 +44,8 @@ import (
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sigstore/fulcio/pkg/audit"
+	"github.com/sigstore/fulcio/pkg/authz"
 	certauth "github.com/sigstore/fulcio/pkg/ca"
 	"github.com/sigstore/fulcio/pkg/ca/ephemeralca"
 	"github.com/sigstore/fulcio/pkg/ca/fileca"
@@ -51,7 +53,11 @@ import (
 	"github.com/sigstore/fulcio/pkg/ca/kmsca"
 	"github.com/sigstore/fulcio/pkg/ca/pkcs11ca"
 	"github.com/sigstore/fulcio/pkg/ca/tinkca"
+	"github.com/sigstore/fulcio/pkg/certstore"
+	"github.com/sigstore/fulcio/pkg/challenges"
 	"github.com/sigstore/fulcio/pkg/config"
+	"github.com/sigstore/fulcio/pkg/ctl"
+	"github.com/sigstore/fulcio/pkg/ctl/fakelog"
 	"github.com/sigstore/fulcio/pkg/generated/protobuf"
 	"github.com/sigstore/fulcio/pkg/generated/protobuf/legacy"
 	"github.com/sigstore/fulcio/pkg/identity"
@@ -64,6 +70,7 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	health "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/keepalive"
 )
 
@@ -91,7 +98,10 @@ func newServeCmd() *cobra.Command {
 	cmd.Flags().String("hsm-caroot-id", "", "HSM ID for Root CA (only used with --ca pkcs11ca)")
 	cmd.Flags().String("ct-log-url", "http://localhost:6962/test", "host and path (with log prefix at the end) to the ct log")
 	cmd.Flags().String("ct-log-public-key-path", "", "Path to a PEM-encoded public key of the CT log, used to verify SCTs")
+	cmd.Flags().String("ct-logs-config", "", "Path to a JSON or YAML file listing several CT logs to submit certificates to, and the quorum of SCTs required. Overrides --ct-log-url")
+	cmd.Flags().Bool("ct-log-fake", false, "Submit certificates to an in-memory CT log with a generated key instead of --ct-log-url. For testing only")
 	cmd.Flags().String("config-path", defaultConfigPath, "path to fulcio config yaml")
+	cmd.Flags().Bool("config-watch", true, "Reload the config and OIDC issuers when the file at --config-path changes")
 	cmd.Flags().String("pkcs11-config-path", "config/crypto11.conf", "path to fulcio pkcs11 config file")
 	cmd.Flags().String("fileca-cert", "", "Path to CA certificate")
 	cmd.Flags().String("fileca-key", "", "Path to CA encrypted private key")
@@ -113,6 +123,30 @@ func newServeCmd() *cobra.Command {
 	cmd.Flags().String("grpc-tls-key", "", "the private key file to use for secure connections (without passphrase) - only applies to grpc-port")
 	cmd.Flags().Duration("idle-connection-timeout", 30*time.Second, "The time allowed for connections (HTTP or gRPC) to go idle before being closed by the server")
 	cmd.Flags().String("ct-log.tls-ca-cert", "", "Path to TLS CA certificate used to connect to ct-log")
+	cmd.Flags().Int("ct-log-max-attempts", ctl.DefaultMaxAttempts, "The number of attempts made to submit a certificate to a CT log, including the first")
+	cmd.Flags().Duration("ct-log-attempt-timeout", ctl.DefaultAttemptTimeout, "The time allowed for each attempt to submit a certificate to a CT log")
+	cmd.Flags().Duration("ct-log-retry-backoff", ctl.DefaultInitialBackoff, "The delay before retrying a failed CT log submission, doubling for every retry")
+	cmd.Flags().Duration("ct-log-max-retry-backoff", ctl.DefaultMaxBackoff, "The maximum delay before retrying a failed CT log submission")
+	cmd.Flags().Int("ct-log-breaker-threshold", ctl.DefaultBreakerThreshold, "The number of consecutive failed attempts after which submissions to a CT log are rejected, or 0 to never reject them")
+	cmd.Flags().Duration("ct-log-breaker-cooldown", ctl.DefaultBreakerCooldown, "The time for which submissions to a CT log are rejected before it is probed again")
+	cmd.Flags().Duration("health-check-interval", 30*time.Second, "How often to probe the CA, CT log and OIDC issuers to determine the health status")
+	cmd.Flags().Duration("health-check-timeout", 10*time.Second, "The time allowed for each health check probe of the CA, CT log or an OIDC issuer")
+	cmd.Flags().Bool("health-check-ca-signature", false, "Probe the CA by creating a test signature on every health check, to detect an unavailable KMS or HSM. Each probe is a signing operation that counts against the KMS or HSM quota and may be billed, so consider a longer --health-check-interval")
+	cmd.Flags().String("audit-log-file", "", "Path to a file to write JSON lines audit events for certificate requests to")
+	cmd.Flags().Int64("audit-log-file-max-size", 100, "The size in megabytes at which the audit log file is rotated, or 0 to disable rotation")
+	cmd.Flags().Int("audit-log-file-max-backups", 10, "The number of rotated audit log files to keep")
+	cmd.Flags().String("audit-webhook-url", "", "URL of an HTTP endpoint to POST audit events for certificate requests to")
+	cmd.Flags().Duration("audit-webhook-timeout", 5*time.Second, "The time allowed for delivering an audit event to the webhook")
+	cmd.Flags().Bool("audit-stdout", false, "Write audit events for certificate requests to stdout")
+	cmd.Flags().Int("token-replay-cache-size", 100000, "Maximum number of used challenge nonces, and tokens of issuers that only accept single-use tokens, remembered")
+	cmd.Flags().String("certificate-store-path", "", "Path to a database file to record issued certificates in")
+	cmd.Flags().Bool("certificate-lookup", false, "Serve the unauthenticated APIs to look up and search the certificates recorded in --certificate-store-path")
+	cmd.Flags().String("challenge-key-path", "", "Path to a file of at least 32 random bytes authenticating challenge nonces, which must be shared by all replicas. A random key is used if unset")
+	cmd.Flags().Duration("challenge-lifetime", challenges.DefaultNonceLifetime, "How long challenge nonces are accepted after they are issued")
+	cmd.Flags().String("authz-webhook-url", "", "URL of an HTTP endpoint, such as an Open Policy Agent data API rule, that must allow each certificate before it is issued")
+	cmd.Flags().Duration("authz-webhook-timeout", 2*time.Second, "The time allowed for the authorization webhook to make a decision")
+	cmd.Flags().Bool("authz-webhook-fail-open", false, "Issue certificates if the authorization webhook can't be reached, rather than failing requests")
+	cmd.Flags().Duration("authz-webhook-cache-ttl", 0, "How long to remember authorization decisions for requests from the same identity, or 0 to disable caching")
 
 	// convert "http-host" flag to "host" and "http-port" flag to be "port"
 	cmd.Flags().SetNormalizeFunc(func(_ *pflag.FlagSet, name string) pflag.NormalizedName {
@@ -208,6 +242,10 @@ func runServeCmd(cmd *cobra.Command, args []string) { //nolint: revive
 		log.Logger.Fatalf("--ca=%s is not a valid selection. Try: pkcs11ca, googleca, fileca, or ephemeralca", viper.GetString("ca"))
 	}
 
+	if viper.GetInt("ct-log-max-attempts") < 1 {
+		log.Logger.Fatalf("--ct-log-max-attempts=%d must be at least 1", viper.GetInt("ct-log-max-attempts"))
+	}
+
 	// Setup the logger to dev/prod
 	log.ConfigureLogger(viper.GetString("log_type"))
 
@@ -272,51 +310,103 @@ func runServeCmd(cmd *cobra.Command, args []string) { //nolint: revive
 	}
 	defer baseca.Close()
 
-	var ctClient *ctclient.LogClient
-	if logURL := viper.GetString("ct-log-url"); logURL != "" {
-		opts := jsonclient.Options{
-			Logger: logAdaptor{logger: log.Logger},
+	var ctLogs *ctl.Logs
+	if logsConfigPath := viper.GetString("ct-logs-config"); logsConfigPath != "" {
+		ctLogs, err = createCTLogs(logsConfigPath)
+		if err != nil {
+			log.Logger.Fatal(err)
 		}
-		// optionally add CT log public key to verify SCTs
-		if pubKeyPath := viper.GetString("ct-log-public-key-path"); pubKeyPath != "" {
-			pemPubKey, err := os.ReadFile(filepath.Clean(pubKeyPath))
-			if err != nil {
-				log.Logger.Fatal(err)
-			}
-			opts.PublicKey = string(pemPubKey)
+	} else if viper.GetBool("ct-log-fake") {
+		fake, err := fakelog.New()
+		if err != nil {
+			log.Logger.Fatal(err)
 		}
-		var httpClient *http.Client
-		if tlsCaCertPath := viper.GetString("ct-log.tls-ca-cert"); tlsCaCertPath != "" {
-			tlsCaCert, err := os.ReadFile(filepath.Clean(tlsCaCertPath))
-			if err != nil {
-				log.Logger.Fatal(err)
-			}
-			caCertPool := x509.NewCertPool()
-			if ok := caCertPool.AppendCertsFromPEM(tlsCaCert); !ok {
-				log.Logger.Fatal("failed to append TLS CA certificate")
-			}
-			tlsConfig := &tls.Config{
-				RootCAs:    caCertPool,
-				MinVersion: tls.VersionTLS12,
-			}
-			transport := &http.Transport{
-				TLSClientConfig: tlsConfig,
-			}
-			httpClient = &http.Client{
-				Timeout:   30 * time.Second,
-				Transport: transport,
-			}
-		} else {
-			httpClient = &http.Client{
-				Timeout: 30 * time.Second,
-			}
+		ctClient, err := fake.Client()
+		if err != nil {
+			log.Logger.Fatal(err)
+		}
+		ctLog, err := ctl.WithSCTVerification(ctClient)
+		if err != nil {
+			log.Logger.Fatal(err)
+		}
+		ctLogs, _ = ctl.NewLogs(1, ctLog)
+		log.Logger.Warn("Submitting certificates to an in-memory CT log, for testing only")
+	} else if logURL := viper.GetString("ct-log-url"); logURL != "" {
+		ctLog, err := createCTLog(ctl.LogConfig{
+			URL:           logURL,
+			PublicKeyPath: viper.GetString("ct-log-public-key-path"),
+			TLSCACertPath: viper.GetString("ct-log.tls-ca-cert"),
+		})
+		if err != nil {
+			log.Logger.Fatal(err)
+		}
+		// A single log can't fail to reach a quorum of 1
+		ctLogs, _ = ctl.NewLogs(1, ctLog)
+	}
+	replayStore, err := identity.NewMemoryReplayStore(viper.GetInt("token-replay-cache-size"))
+	if err != nil {
+		log.Logger.Fatal(err)
+	}
+	reloader := server.NewConfigReloader(cp, cfg, func(cfg *config.FulcioConfig) identity.IssuerPool {
+		return identity.PreventReplay(server.NewIssuerPool(cfg), replayStore)
+	})
+	// Without a config file the defaults are used, and there is nothing to watch
+	if _, err := os.Stat(cp); err == nil && viper.GetBool("config-watch") {
+		if err := reloader.Watch(ctx); err != nil {
+			log.Logger.Fatalf("error watching --config-path=%s: %v", cp, err)
+		}
+	}
+	ip := reloader.IssuerPool()
+
+	var healthOpts []server.HealthCheckerOption
+	if viper.GetBool("health-check-ca-signature") {
+		healthOpts = append(healthOpts, server.WithCASignatureCheck())
+	}
+	healthChecker := server.NewHealthChecker(ctLogs, baseca, reloader, viper.GetDuration("health-check-interval"), viper.GetDuration("health-check-timeout"), healthOpts...)
+	healthChecker.Start(ctx)
+
+	auditLogger, err := createAuditLogger()
+	if err != nil {
+		log.Logger.Fatal(err)
+	}
+	defer auditLogger.Close()
+
+	nonces, err := createNonceIssuer(replayStore)
+	if err != nil {
+		log.Logger.Fatal(err)
+	}
+
+	serverOpts := []server.GRPCCAServerOption{
+		server.WithHealthChecker(healthChecker),
+		server.WithAuditLogger(auditLogger),
+		server.WithChallenges(nonces),
+	}
+
+	if ctLogs != nil {
+		serverOpts = append(serverOpts, server.WithCTLogs(ctLogs))
+	}
+
+	if webhookURL := viper.GetString("authz-webhook-url"); webhookURL != "" {
+		webhook, err := createAuthorizationWebhook(webhookURL)
+		if err != nil {
+			log.Logger.Fatal(err)
 		}
-		ctClient, err = ctclient.New(logURL, httpClient, opts)
+		serverOpts = append(serverOpts, server.WithAuthorizationWebhook(webhook))
+	}
+
+	if storePath := viper.GetString("certificate-store-path"); storePath != "" {
+		store, err := certstore.NewBoltStore(storePath)
 		if err != nil {
 			log.Logger.Fatal(err)
 		}
+		defer store.Close()
+		serverOpts = append(serverOpts, server.WithCertificateStore(store))
+		if viper.GetBool("certificate-lookup") {
+			serverOpts = append(serverOpts, server.WithCertificateLookup())
+		}
+	} else if viper.GetBool("certificate-lookup") {
+		log.Logger.Fatal("--certificate-lookup requires --certificate-store-path")
 	}
-	ip := server.NewIssuerPool(cfg)
 
 	portsMatch := viper.GetString("port") == viper.GetString("grpc-port")
 	hostsMatch := viper.GetString("host") == viper.GetString("grpc-host")
@@ -324,7 +414,7 @@ func runServeCmd(cmd *cobra.Command, args []string) { //nolint: revive
 		port := viper.GetInt("port")
 		metricsPort := viper.GetInt("metrics-port")
 		// StartDuplexServer will always return an error, log fatally if it's non-nil
-		if err := StartDuplexServer(ctx, cfg, ctClient, baseca, viper.GetString("host"), port, metricsPort, ip); err != http.ErrServerClosed {
+		if err := StartDuplexServer(ctx, reloader, baseca, viper.GetString("host"), port, metricsPort, ip, auditLogger, serverOpts...); err != http.ErrServerClosed {
 			log.Logger.Fatal(err)
 		}
 		return
@@ -337,14 +427,14 @@ func runServeCmd(cmd *cobra.Command, args []string) { //nolint: revive
 
 	reg := prometheus.NewRegistry()
 
-	grpcServer, err := createGRPCServer(cfg, ctClient, baseca, ip)
+	grpcServer, err := createGRPCServer(reloader, baseca, ip, auditLogger, serverOpts...)
 	if err != nil {
 		log.Logger.Fatal(err)
 	}
 	grpcServer.setupPrometheus(reg)
 	grpcServer.startTCPListener(&wg)
 
-	legacyGRPCServer, err := createLegacyGRPCServer(cfg, viper.GetString("legacy-unix-domain-socket"), grpcServer.caService)
+	legacyGRPCServer, err := createLegacyGRPCServer(reloader, viper.GetString("legacy-unix-domain-socket"), grpcServer.caService, grpcServer.rateLimiter)
 	if err != nil {
 		log.Logger.Fatal(err)
 	}
@@ -384,6 +474,174 @@ func runServeCmd(cmd *cobra.Command, args []string) { //nolint: revive
 	wg.Wait()
 }
 
+// createAuditLogger returns a logger writing audit events to the sinks
+// enabled by flags, or nil if no sink is enabled.
+func createAuditLogger() (*audit.Logger, error) {
+	var sinks []audit.Sink
+	if path := viper.GetString("audit-log-file"); path != "" {
+		fileSink, err := audit.NewFileSink(path, viper.GetInt64("audit-log-file-max-size")*1024*1024, viper.GetInt("audit-log-file-max-backups"))
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, fileSink)
+	}
+	if webhookURL := viper.GetString("audit-webhook-url"); webhookURL != "" {
+		sinks = append(sinks, audit.NewWebhookSink(webhookURL, &http.Client{
+			Timeout: viper.GetDuration("audit-webhook-timeout"),
+		}))
+	}
+	if viper.GetBool("audit-stdout") {
+		sinks = append(sinks, audit.NewWriterSink(os.Stdout))
+	}
+	if len(sinks) == 0 {
+		return nil, nil
+	}
+	return audit.NewLogger(viper.GetString("ca"), sinks...), nil
+}
+
+// createNonceIssuer returns the issuer of challenge nonces, with the key
+// from the file set by flag or a random key, recording used nonces in store.
+func createNonceIssuer(store identity.ReplayStore) (*challenges.NonceIssuer, error) {
+	var key []byte
+	if path := viper.GetString("challenge-key-path"); path != "" {
+		var err error
+		key, err = os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return nil, fmt.Errorf("reading challenge key: %w", err)
+		}
+	} else {
+		log.Logger.Warn("--challenge-key-path is not set, challenges are only accepted by the replica that issued them")
+	}
+	return challenges.NewNonceIssuer(key, viper.GetDuration("challenge-lifetime"), store)
+}
+
+// createCTLogClient returns a client for the CT log at logURL, verifying SCTs
+// with the public key at pubKeyPath and trusting the TLS CA certificates at
+// tlsCACertPath if they are set.
+func createCTLogClient(logURL, pubKeyPath, tlsCACertPath string) (*ctclient.LogClient, error) {
+	opts := jsonclient.Options{
+		Logger: logAdaptor{logger: log.Logger},
+	}
+	// optionally add CT log public key to verify SCTs
+	if pubKeyPath != "" {
+		pemPubKey, err := os.ReadFile(filepath.Clean(pubKeyPath))
+		if err != nil {
+			return nil, err
+		}
+		opts.PublicKey = string(pemPubKey)
+	}
+	httpClient, err := createCTHTTPClient(tlsCACertPath)
+	if err != nil {
+		return nil, err
+	}
+	return ctclient.New(logURL, httpClient, opts)
+}
+
+// createCTHTTPClient returns the HTTP client for connecting to a CT log,
+// trusting the TLS CA certificates at tlsCACertPath if it is set.
+func createCTHTTPClient(tlsCACertPath string) (*http.Client, error) {
+	httpClient := &http.Client{
+		Timeout: 30 * time.Second,
+	}
+	if tlsCACertPath != "" {
+		tlsCaCert, err := os.ReadFile(filepath.Clean(tlsCACertPath))
+		if err != nil {
+			return nil, err
+		}
+		caCertPool := x509.NewCertPool()
+		if ok := caCertPool.AppendCertsFromPEM(tlsCaCert); !ok {
+			return nil, errors.New("failed to append TLS CA certificate")
+		}
+		httpClient.Transport = &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:    caCertPool,
+				MinVersion: tls.VersionTLS12,
+			},
+		}
+	}
+	return httpClient, nil
+}
+
+// createCTLog returns the CT log configured by cfg, verifying SCTs with its
+// public key if it is set, and retrying failed submissions as configured by
+// flags.
+func createCTLog(cfg ctl.LogConfig) (ctl.Log, error) {
+	client, err := createCTLogClient(cfg.URL, cfg.PublicKeyPath, cfg.TLSCACertPath)
+	if err != nil {
+		return nil, err
+	}
+	var ctLog ctl.Log = client
+	if cfg.Type == ctl.LogTypeStaticCTAPI {
+		httpClient, err := createCTHTTPClient(cfg.TLSCACertPath)
+		if err != nil {
+			return nil, err
+		}
+		ctLog = ctl.NewStaticLog(client, cfg.MonitoringURL, httpClient)
+	}
+	verifying, err := ctl.WithSCTVerification(ctLog)
+	if err != nil {
+		return nil, err
+	}
+	return ctl.NewRetryingLog(cfg.URL, verifying,
+		ctl.WithMaxAttempts(viper.GetInt("ct-log-max-attempts")),
+		ctl.WithAttemptTimeout(viper.GetDuration("ct-log-attempt-timeout")),
+		ctl.WithBackoff(viper.GetDuration("ct-log-retry-backoff"), viper.GetDuration("ct-log-max-retry-backoff")),
+		ctl.WithCircuitBreaker(viper.GetInt("ct-log-breaker-threshold"), viper.GetDuration("ct-log-breaker-cooldown")),
+	), nil
+}
+
+// createCTLogs returns the CT logs listed in the configuration at path.
+func createCTLogs(path string) (*ctl.Logs, error) {
+	b, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("reading CT logs configuration: %w", err)
+	}
+	cfg, err := ctl.ReadLogsConfig(b)
+	if err != nil {
+		return nil, fmt.Errorf("parsing CT logs configuration: %w", err)
+	}
+	var logs []ctl.Log
+	for _, l := range cfg.Logs {
+		if len(l.Shards) == 0 {
+			ctLog, err := createCTLog(l)
+			if err != nil {
+				return nil, fmt.Errorf("creating client for CT log %s: %w", l.URL, err)
+			}
+			logs = append(logs, ctLog)
+			continue
+		}
+		var shards []ctl.Shard
+		for _, s := range l.Shards {
+			ctLog, err := createCTLog(s)
+			if err != nil {
+				return nil, fmt.Errorf("creating client for CT log shard %s: %w", s.URL, err)
+			}
+			shards = append(shards, ctl.Shard{Log: ctLog, NotAfterStart: s.NotAfterStart, NotAfterLimit: s.NotAfterLimit})
+		}
+		sharded, err := ctl.NewShardedLog(shards...)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, sharded)
+	}
+	return ctl.NewLogs(cfg.Quorum, logs...)
+}
+
+// createAuthorizationWebhook returns the webhook authorizing issuance,
+// configured by flags.
+func createAuthorizationWebhook(webhookURL string) (*authz.Webhook, error) {
+	var opts []authz.WebhookOption
+	if viper.GetBool("authz-webhook-fail-open") {
+		opts = append(opts, authz.WithFailOpen())
+	}
+	if ttl := viper.GetDuration("authz-webhook-cache-ttl"); ttl > 0 {
+		opts = append(opts, authz.WithCache(ttl, authz.DefaultCacheSize))
+	}
+	return authz.NewWebhook(webhookURL, &http.Client{
+		Timeout: viper.GetDuration("authz-webhook-timeout"),
+	}, opts...)
+}
+
 func checkServeCmdConfigFile() error {
 	if serveCmdConfigFilePath != "" {
 		if _, err := os.Stat(serveCmdConfigFilePath); err != nil {
@@ -415,8 +673,9 @@ func checkServeCmdConfigFile() error {
 	return nil
 }
 
-func StartDuplexServer(ctx context.Context, cfg *config.FulcioConfig, ctClient *ctclient.LogClient, baseca certauth.CertificateAuthority, host string, port, metricsPort int, ip identity.IssuerPool) error {
-	logger, opts := log.SetupGRPCLogging()
+func StartDuplexServer(ctx context.Context, cfg *server.ConfigReloader, baseca certauth.CertificateAuthority, host string, port, metricsPort int, ip identity.IssuerPool, auditLogger *audit.Logger, opts ...server.GRPCCAServerOption) error {
+	logger, logOpts := log.SetupGRPCLogging()
+	rateLimiter := server.NewRateLimiter(ip, server.WithRateLimiterAuditLogger(auditLogger))
 
 	d := duplex.New(
 		port,
@@ -428,16 +687,19 @@ func StartDuplexServer(ctx context.Context, cfg *config.FulcioConfig, ctClient *
 		grpc.UnaryInterceptor(grpcmw.ChainUnaryServer(
 			grpc_recovery.UnaryServerInterceptor(grpc_recovery.WithRecoveryHandlerContext(panicRecoveryHandler)), // recovers from per-transaction panics elegantly, so put it first
 			middleware.UnaryRequestID(middleware.UseXRequestIDMetadataOption(true), middleware.XRequestMetadataLimitOption(128)),
-			grpc_zap.UnaryServerInterceptor(logger, opts...),
+			grpc_zap.UnaryServerInterceptor(logger, logOpts...),
 			PassFulcioConfigThruContext(cfg),
+			rateLimiter.UnaryServerInterceptor(), // requires the config from the context
 			grpc_prometheus.UnaryServerInterceptor,
 		)),
 		grpc.MaxRecvMsgSize(int(maxMsgSize)),
 		runtime.WithForwardResponseOption(setResponseCodeModifier),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
 	)
 
 	// GRPC server
-	grpcCAServer := server.NewGRPCCAServer(ctClient, baseca, ip)
+	grpcCAServer := server.NewGRPCCAServer(baseca, ip, opts...)
+	health.RegisterHealthServer(d.Server, grpcCAServer)
 	protobuf.RegisterCAServer(d.Server, grpcCAServer)
 	if err := d.RegisterHandler(ctx, protobuf.RegisterCAHandlerFromEndpoint); err != nil {
 		return fmt.Errorf("registering grpc ca handler: %w", err)
@@ -0,0 +1,174 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package audit records structured events about certificate issuance so that
+// it can be reconstructed which identity was issued which certificate.
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	ct "github.com/google/certificate-transparency-go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/sigstore/fulcio/pkg/log"
+)
+
+var metricSinkErrors = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "fulcio_audit_sink_errors",
+	Help: "The total number of audit events that could not be written to a sink",
+})
+
+// Outcome is the result of a certificate request.
+type Outcome string
+
+const (
+	OutcomeSuccess Outcome = "success"
+	OutcomeFailure Outcome = "failure"
+)
+
+// Event describes a single certificate request. Fields that are not known for
+// a request, such as the serial number of a failed request, are omitted.
+type Event struct {
+	Time      time.Time `json:"time"`
+	Outcome   Outcome   `json:"outcome"`
+	RequestID string    `json:"requestId,omitempty"`
+	CABackend string    `json:"caBackend,omitempty"`
+
+	// Identity of the requester
+	Issuer     string `json:"issuer,omitempty"`
+	IssuerType string `json:"issuerType,omitempty"`
+	Principal  string `json:"principal,omitempty"`
+
+	// Issued certificate
+	Serial                  string   `json:"serial,omitempty"`
+	SubjectAlternativeNames []string `json:"subjectAlternativeNames,omitempty"`
+	// Hex-encoded SHA-256 digest of the DER-encoded SubjectPublicKeyInfo
+	PublicKeyFingerprint string     `json:"publicKeyFingerprint,omitempty"`
+	NotBefore            *time.Time `json:"notBefore,omitempty"`
+	NotAfter             *time.Time `json:"notAfter,omitempty"`
+
+	// Signed certificate timestamp from the first CT log
+	SCTLogID     string     `json:"sctLogId,omitempty"`
+	SCTTimestamp *time.Time `json:"sctTimestamp,omitempty"`
+	// Log IDs of every CT log that returned an SCT
+	SCTLogIDs []string `json:"sctLogIds,omitempty"`
+
+	// Failed requests: the gRPC status code, the reason code of the error,
+	// such as TOKEN_EXPIRED, and the message returned to the client
+	Code    string `json:"code,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// SetCertificate fills in the details of an issued certificate.
+func (e *Event) SetCertificate(cert *x509.Certificate) {
+	if cert == nil {
+		return
+	}
+	e.Serial = cert.SerialNumber.String()
+	e.SubjectAlternativeNames = append(e.SubjectAlternativeNames, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		e.SubjectAlternativeNames = append(e.SubjectAlternativeNames, uri.String())
+	}
+	e.SubjectAlternativeNames = append(e.SubjectAlternativeNames, cert.DNSNames...)
+	digest := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	e.PublicKeyFingerprint = hex.EncodeToString(digest[:])
+	notBefore, notAfter := cert.NotBefore.UTC(), cert.NotAfter.UTC()
+	e.NotBefore, e.NotAfter = &notBefore, &notAfter
+}
+
+// SetSCTs fills in the log ID and timestamp of the first of the signed
+// certificate timestamps, and the log IDs of all of them.
+func (e *Event) SetSCTs(scts []*ct.SignedCertificateTimestamp) {
+	if len(scts) == 0 {
+		return
+	}
+	e.SetSCT(scts[0])
+	for _, sct := range scts {
+		e.SCTLogIDs = append(e.SCTLogIDs, base64.StdEncoding.EncodeToString(sct.LogID.KeyID[:]))
+	}
+}
+
+// SetSCT fills in the log ID and timestamp of a signed certificate timestamp.
+func (e *Event) SetSCT(sct *ct.SignedCertificateTimestamp) {
+	if sct == nil {
+		return
+	}
+	e.SCTLogID = base64.StdEncoding.EncodeToString(sct.LogID.KeyID[:])
+	timestamp := time.UnixMilli(int64(sct.Timestamp)).UTC()
+	e.SCTTimestamp = &timestamp
+}
+
+// Sink is a destination for audit events. Implementations must be safe for
+// concurrent use.
+type Sink interface {
+	Write(ctx context.Context, event *Event) error
+	Close() error
+}
+
+// Logger writes audit events to a set of sinks. A nil Logger discards all
+// events.
+type Logger struct {
+	caBackend string
+	sinks     []Sink
+}
+
+// NewLogger returns a Logger that writes events to every sink, recording
+// caBackend as the CA that issued the certificates.
+func NewLogger(caBackend string, sinks ...Sink) *Logger {
+	return &Logger{
+		caBackend: caBackend,
+		sinks:     sinks,
+	}
+}
+
+// Record stamps the event with the current time, the request ID and the CA
+// backend and writes it to every sink. Failures to write to a sink are logged
+// rather than returned, so that auditing never fails a request that has
+// already been served.
+func (l *Logger) Record(ctx context.Context, event *Event) {
+	if l == nil {
+		return
+	}
+	event.Time = time.Now().UTC()
+	event.RequestID = log.RequestID(ctx)
+	event.CABackend = l.caBackend
+	for _, sink := range l.sinks {
+		if err := sink.Write(ctx, event); err != nil {
+			metricSinkErrors.Inc()
+			log.ContextLogger(ctx).Errorw("error writing audit event", "error", err, "event", event)
+		}
+	}
+}
+
+// Close closes every sink.
+func (l *Logger) Close() error {
+	if l == nil {
+		return nil
+	}
+	var errs []error
+	for _, sink := range l.sinks {
+		errs = append(errs, sink.Close())
+	}
+	return errors.Join(errs...)
+}
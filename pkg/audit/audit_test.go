@@ -0,0 +1,106 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	ct "github.com/google/certificate-transparency-go"
+	"google.golang.org/grpc/metadata"
+)
+
+type failingSink struct{}
+
+func (failingSink) Write(context.Context, *Event) error { return errors.New("failed") }
+func (failingSink) Close() error                        { return errors.New("failed") }
+
+func TestLoggerRecord(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger("ephemeralca", failingSink{}, NewWriterSink(&buf))
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "abc"))
+	uri, _ := url.Parse("https://github.com/sigstore/fulcio/.github/workflows/release.yml@refs/heads/main")
+	event := &Event{
+		Outcome:   OutcomeSuccess,
+		Issuer:    "https://token.actions.githubusercontent.com",
+		Principal: "repo:sigstore/fulcio:ref:refs/heads/main",
+	}
+	event.SetCertificate(&x509.Certificate{
+		SerialNumber:            big.NewInt(1234),
+		URIs:                    []*url.URL{uri},
+		RawSubjectPublicKeyInfo: []byte("key"),
+		NotBefore:               time.Unix(0, 0),
+		NotAfter:                time.Unix(600, 0),
+	})
+	event.SetSCTs([]*ct.SignedCertificateTimestamp{{
+		LogID:     ct.LogID{KeyID: [32]byte{1}},
+		Timestamp: 1000,
+	}, {
+		LogID:     ct.LogID{KeyID: [32]byte{2}},
+		Timestamp: 2000,
+	}})
+	// A failing sink doesn't prevent writing to the others
+	l.Record(ctx, event)
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshalling event: %v", err)
+	}
+	want := map[string]interface{}{
+		"outcome":                 "success",
+		"requestId":               "abc",
+		"caBackend":               "ephemeralca",
+		"serial":                  "1234",
+		"subjectAlternativeNames": []interface{}{uri.String()},
+		// sha256("key")
+		"publicKeyFingerprint": "2c70e12b7a0646f92279f427c7b38e7334d8e5389cff167a1dc30e73f826b683",
+		"sctLogId":             "AQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=",
+		"sctTimestamp":         "1970-01-01T00:00:01Z",
+		"sctLogIds":            []interface{}{"AQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=", "AgAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="},
+		"notAfter":             "1970-01-01T00:10:00Z",
+	}
+	for k, v := range want {
+		gotV, _ := json.Marshal(got[k])
+		wantV, _ := json.Marshal(v)
+		if string(gotV) != string(wantV) {
+			t.Errorf("%s = %s, wanted %s", k, gotV, wantV)
+		}
+	}
+	if _, ok := got["reason"]; ok {
+		t.Error("expected no reason for a successful request")
+	}
+	if !strings.HasSuffix(buf.String(), "}\n") {
+		t.Error("expected a JSON line")
+	}
+
+	if err := l.Close(); err == nil {
+		t.Error("expected error closing failing sink")
+	}
+}
+
+func TestNilLogger(_ *testing.T) {
+	var l *Logger
+	l.Record(context.Background(), &Event{Outcome: OutcomeFailure})
+	_ = l.Close()
+}
@@ -0,0 +1,103 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func readEvents(t *testing.T, path string) []Event {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("opening %s: %v", path, err)
+	}
+	defer f.Close()
+	var events []Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("unmarshalling %q: %v", scanner.Text(), err)
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+func TestFileSinkRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	event := &Event{Outcome: OutcomeSuccess, Serial: "1"}
+	line, _ := json.Marshal(event)
+
+	// Room for two events per file, keeping two backups
+	s, err := NewFileSink(path, int64(2*(len(line)+1)), 2)
+	if err != nil {
+		t.Fatalf("NewFileSink() = %v", err)
+	}
+	for _, serial := range []string{"1", "2", "3", "4", "5", "6", "7"} {
+		if err := s.Write(context.Background(), &Event{Outcome: OutcomeSuccess, Serial: serial}); err != nil {
+			t.Fatalf("Write() = %v", err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close() = %v", err)
+	}
+
+	for file, want := range map[string][]string{
+		path:        {"7"},
+		path + ".1": {"5", "6"},
+		path + ".2": {"3", "4"},
+	} {
+		events := readEvents(t, file)
+		if len(events) != len(want) {
+			t.Fatalf("%s: got %d events, wanted %d", file, len(events), len(want))
+		}
+		for i, e := range events {
+			if e.Serial != want[i] {
+				t.Errorf("%s: got serial %s, wanted %s", file, e.Serial, want[i])
+			}
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("expected oldest backup to be removed, got %v", err)
+	}
+
+	if err := s.Write(context.Background(), event); err == nil {
+		t.Error("expected error writing to closed sink")
+	}
+}
+
+func TestFileSinkAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	for i := 0; i < 2; i++ {
+		s, err := NewFileSink(path, 0, 0)
+		if err != nil {
+			t.Fatalf("NewFileSink() = %v", err)
+		}
+		if err := s.Write(context.Background(), &Event{Outcome: OutcomeFailure}); err != nil {
+			t.Fatalf("Write() = %v", err)
+		}
+		s.Close()
+	}
+	if events := readEvents(t, path); len(events) != 2 {
+		t.Fatalf("got %d events, wanted 2", len(events))
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWebhookSink(t *testing.T) {
+	received := make(chan Event, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			http.Error(w, "bad content type", http.StatusUnsupportedMediaType)
+			return
+		}
+		var e Event
+		if err := json.NewDecoder(r.Body).Decode(&e); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		received <- e
+	}))
+	defer srv.Close()
+
+	s := NewWebhookSink(srv.URL, &http.Client{Timeout: 5 * time.Second})
+	defer s.Close()
+	if err := s.Write(context.Background(), &Event{Outcome: OutcomeFailure, Reason: "TOKEN_EXPIRED"}); err != nil {
+		t.Fatalf("Write() = %v", err)
+	}
+	if e := <-received; e.Reason != "TOKEN_EXPIRED" {
+		t.Errorf("got reason %q, wanted %q", e.Reason, "TOKEN_EXPIRED")
+	}
+}
+
+func TestWebhookSinkError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	s := NewWebhookSink(srv.URL, &http.Client{Timeout: 5 * time.Second})
+	if err := s.Write(context.Background(), &Event{Outcome: OutcomeSuccess}); err == nil {
+		t.Error("expected error for unsuccessful status")
+	}
+}
@@ -0,0 +1,263 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package authz asks an external policy service, such as an Open Policy Agent
+// sidecar, whether a certificate may be issued.
+package authz
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/sigstore/fulcio/pkg/log"
+)
+
+// DefaultCacheSize is the number of decisions cached by a Webhook.
+const DefaultCacheSize = 10000
+
+var metricDecisions = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "fulcio_authz_webhook_decisions",
+	Help: "The total number of authorization webhook decisions, by result",
+}, []string{"result"})
+
+// ErrUnavailable is returned when the webhook could not be asked for a
+// decision and the webhook fails closed.
+var ErrUnavailable = errors.New("authorization webhook unavailable")
+
+// DeniedError is returned when the webhook denies a request.
+type DeniedError struct {
+	// Reason optionally explains the decision to the client
+	Reason string
+}
+
+func (e *DeniedError) Error() string {
+	if e.Reason == "" {
+		return "denied by authorization webhook"
+	}
+	return "denied by authorization webhook: " + e.Reason
+}
+
+// Request describes a certificate that is about to be issued.
+type Request struct {
+	Issuer    string `json:"issuer"`
+	Principal string `json:"principal"`
+	// Claims of the verified token, if authenticated with a token
+	Claims map[string]any `json:"claims,omitempty"`
+	// Hex-encoded SHA-256 digest of the DER-encoded SubjectPublicKeyInfo
+	PublicKeyFingerprint    string   `json:"publicKeyFingerprint"`
+	SubjectAlternativeNames []string `json:"subjectAlternativeNames"`
+}
+
+// Decision is the response of the webhook to a Request.
+type Decision struct {
+	Allow  bool   `json:"allow"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type cachedDecision struct {
+	decision Decision
+	expiry   time.Time
+}
+
+// WebhookOption configures a Webhook.
+type WebhookOption func(*Webhook)
+
+// WithFailOpen allows requests when the webhook can't be reached or returns
+// an invalid response, rather than failing them.
+func WithFailOpen() WebhookOption {
+	return func(w *Webhook) {
+		w.failOpen = true
+	}
+}
+
+// WithCache remembers decisions for requests from the same identity for ttl.
+// Requests are keyed on their issuer, principal, subject alternative names and
+// claims, ignoring the public key and the claims that differ between tokens
+// for the same identity, such as the token ID and timestamps. Policies that
+// depend on those should not be cached.
+func WithCache(ttl time.Duration, size int) WebhookOption {
+	return func(w *Webhook) {
+		w.cacheTTL = ttl
+		w.cacheSize = size
+	}
+}
+
+// Webhook POSTs requests to an HTTP endpoint that decides whether they are
+// allowed. The request and response bodies follow the Open Policy Agent data
+// API: the request is sent as {"input": <Request>}, and the response is
+// {"result": <Decision>}, where the result may also be a bare boolean. A
+// missing result, such as for an undefined OPA rule, denies the request.
+type Webhook struct {
+	url      string
+	client   *http.Client
+	failOpen bool
+
+	cacheTTL  time.Duration
+	cacheSize int
+	cache     *lru.Cache
+}
+
+// NewWebhook returns a webhook posting to url. The client should have a
+// timeout set, since issuance waits for the decision.
+func NewWebhook(url string, client *http.Client, opts ...WebhookOption) (*Webhook, error) {
+	w := &Webhook{
+		url:    url,
+		client: client,
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	if w.cacheTTL > 0 {
+		cache, err := lru.New(w.cacheSize)
+		if err != nil {
+			return nil, fmt.Errorf("creating decision cache: %w", err)
+		}
+		w.cache = cache
+	}
+	return w, nil
+}
+
+// Authorize returns nil if the webhook allows the request, a *DeniedError if
+// it denies it, or an error wrapping ErrUnavailable if no decision could be
+// made and the webhook fails closed.
+func (w *Webhook) Authorize(ctx context.Context, request *Request) error {
+	body, err := json.Marshal(struct {
+		Input *Request `json:"input"`
+	}{request})
+	if err != nil {
+		return err
+	}
+	key, err := cacheKey(request)
+	if err != nil {
+		return err
+	}
+
+	decision, ok := w.cached(key)
+	if !ok {
+		decision, err = w.decide(ctx, body)
+		if err != nil {
+			metricDecisions.WithLabelValues("error").Inc()
+			if w.failOpen {
+				log.ContextLogger(ctx).Warnw("authorization webhook failed, allowing request", "error", err)
+				return nil
+			}
+			return fmt.Errorf("%w: %w", ErrUnavailable, err)
+		}
+		w.store(key, decision)
+	}
+
+	if !decision.Allow {
+		metricDecisions.WithLabelValues("deny").Inc()
+		return &DeniedError{Reason: decision.Reason}
+	}
+	metricDecisions.WithLabelValues("allow").Inc()
+	return nil
+}
+
+func (w *Webhook) decide(ctx context.Context, body []byte) (Decision, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return Decision{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return Decision{}, fmt.Errorf("posting authorization request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return Decision{}, fmt.Errorf("posting authorization request: unexpected status %s", resp.Status)
+	}
+
+	var response struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return Decision{}, fmt.Errorf("decoding authorization response: %w", err)
+	}
+	var decision Decision
+	if len(response.Result) == 0 {
+		return decision, nil
+	}
+	if err := json.Unmarshal(response.Result, &decision.Allow); err == nil {
+		return decision, nil
+	}
+	if err := json.Unmarshal(response.Result, &decision); err != nil {
+		return Decision{}, fmt.Errorf("decoding authorization decision: %w", err)
+	}
+	return decision, nil
+}
+
+// volatileClaims differ between tokens for the same identity, so they are
+// left out of cache keys.
+var volatileClaims = map[string]bool{
+	"jti":       true,
+	"iat":       true,
+	"nbf":       true,
+	"exp":       true,
+	"auth_time": true,
+	"nonce":     true,
+	"at_hash":   true,
+	"c_hash":    true,
+}
+
+// cacheKey returns the key of the cached decision for request.
+func cacheKey(request *Request) ([sha256.Size]byte, error) {
+	claims := make(map[string]any, len(request.Claims))
+	for name, value := range request.Claims {
+		if !volatileClaims[name] {
+			claims[name] = value
+		}
+	}
+	b, err := json.Marshal(&Request{
+		Issuer:                  request.Issuer,
+		Principal:               request.Principal,
+		Claims:                  claims,
+		SubjectAlternativeNames: request.SubjectAlternativeNames,
+	})
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+	return sha256.Sum256(b), nil
+}
+
+func (w *Webhook) cached(key [sha256.Size]byte) (Decision, bool) {
+	if w.cache == nil {
+		return Decision{}, false
+	}
+	if c, ok := w.cache.Get(key); ok && time.Now().Before(c.(cachedDecision).expiry) {
+		return c.(cachedDecision).decision, true
+	}
+	return Decision{}, false
+}
+
+func (w *Webhook) store(key [sha256.Size]byte, decision Decision) {
+	if w.cache == nil {
+		return
+	}
+	w.cache.Add(key, cachedDecision{decision: decision, expiry: time.Now().Add(w.cacheTTL)})
+}
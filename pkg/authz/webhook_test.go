@@ -0,0 +1,168 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWebhookAuthorize(t *testing.T) {
+	tests := map[string]struct {
+		response   string
+		wantErr    bool
+		wantReason string
+	}{
+		"allow":             {`{"result": {"allow": true}}`, false, ""},
+		"allow boolean":     {`{"result": true}`, false, ""},
+		"deny":              {`{"result": {"allow": false}}`, true, ""},
+		"deny with reason":  {`{"result": {"allow": false, "reason": "not a release"}}`, true, "not a release"},
+		"deny boolean":      {`{"result": false}`, true, ""},
+		"undefined result":  {`{}`, true, ""},
+		"reason when allow": {`{"result": {"allow": true, "reason": "ok"}}`, false, ""},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body struct {
+					Input Request `json:"input"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				if body.Input.Principal != "foo@example.com" || body.Input.Claims["email"] != "foo@example.com" {
+					http.Error(w, "unexpected input", http.StatusBadRequest)
+					return
+				}
+				fmt.Fprint(w, test.response)
+			}))
+			defer srv.Close()
+
+			w, err := NewWebhook(srv.URL, &http.Client{Timeout: 5 * time.Second})
+			if err != nil {
+				t.Fatalf("NewWebhook() = %v", err)
+			}
+			err = w.Authorize(context.Background(), &Request{
+				Issuer:                  "https://issuer.example.com",
+				Principal:               "foo@example.com",
+				Claims:                  map[string]any{"email": "foo@example.com"},
+				PublicKeyFingerprint:    "abcd",
+				SubjectAlternativeNames: []string{"foo@example.com"},
+			})
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Authorize() = %v, wantErr %v", err, test.wantErr)
+			}
+			if err == nil {
+				return
+			}
+			var denied *DeniedError
+			if !errors.As(err, &denied) {
+				t.Fatalf("Authorize() = %v, wanted DeniedError", err)
+			}
+			if denied.Reason != test.wantReason {
+				t.Errorf("got reason %q, wanted %q", denied.Reason, test.wantReason)
+			}
+		})
+	}
+}
+
+func TestWebhookUnavailable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+		fmt.Fprint(w, `{"result": true}`)
+	}))
+	defer slow.Close()
+
+	for _, url := range []string{srv.URL, slow.URL} {
+		closed, err := NewWebhook(url, &http.Client{Timeout: 100 * time.Millisecond})
+		if err != nil {
+			t.Fatalf("NewWebhook() = %v", err)
+		}
+		if err := closed.Authorize(context.Background(), &Request{}); !errors.Is(err, ErrUnavailable) {
+			t.Errorf("Authorize() = %v, wanted ErrUnavailable", err)
+		}
+
+		open, err := NewWebhook(url, &http.Client{Timeout: 100 * time.Millisecond}, WithFailOpen())
+		if err != nil {
+			t.Fatalf("NewWebhook() = %v", err)
+		}
+		if err := open.Authorize(context.Background(), &Request{}); err != nil {
+			t.Errorf("Authorize() = %v, wanted requests allowed when failing open", err)
+		}
+	}
+}
+
+func TestWebhookCache(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		fmt.Fprint(w, `{"result": {"allow": false, "reason": "denied"}}`)
+	}))
+	defer srv.Close()
+
+	w, err := NewWebhook(srv.URL, &http.Client{Timeout: 5 * time.Second}, WithCache(time.Minute, DefaultCacheSize))
+	if err != nil {
+		t.Fatalf("NewWebhook() = %v", err)
+	}
+	// Fresh tokens and keys for the same identity are answered from the cache
+	for i := 0; i < 3; i++ {
+		request := &Request{
+			Issuer:    "https://issuer.example.com",
+			Principal: "foo@example.com",
+			Claims: map[string]any{
+				"sub": "foo@example.com",
+				"jti": fmt.Sprintf("token-%d", i),
+				"iat": float64(time.Now().Unix() + int64(i)),
+				"exp": float64(time.Now().Unix() + int64(i) + 600),
+			},
+			PublicKeyFingerprint: fmt.Sprintf("%064x", i),
+		}
+		if err := w.Authorize(context.Background(), request); err == nil {
+			t.Fatal("expected request to be denied")
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("webhook called %d times, wanted 1", got)
+	}
+	// Requests for a different identity, or with different claims, are not
+	// answered from the cache
+	others := []*Request{
+		{Issuer: "https://issuer.example.com", Principal: "bar@example.com", Claims: map[string]any{"sub": "bar@example.com"}},
+		{Issuer: "https://issuer.example.com", Principal: "foo@example.com", Claims: map[string]any{"sub": "foo@example.com", "ref": "refs/heads/main"}},
+	}
+	for _, request := range others {
+		if err := w.Authorize(context.Background(), request); err == nil {
+			t.Fatal("expected request to be denied")
+		}
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("webhook called %d times, wanted 3", got)
+	}
+}
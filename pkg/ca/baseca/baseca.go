@@ -22,6 +22,7 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/asn1"
+	"errors"
 
 	ct "github.com/google/certificate-transparency-go"
 	cttls "github.com/google/certificate-transparency-go/tls"
@@ -98,7 +99,7 @@ func generateSCTListExt(scts []ct.SignedCertificateTimestamp) (pkix.Extension, e
 	}, nil
 }
 
-func (bca *BaseCA) IssueFinalCertificate(_ context.Context, precert *ca.CodeSigningPreCertificate, sct *ct.SignedCertificateTimestamp) (*ca.CodeSigningCertificate, error) {
+func (bca *BaseCA) IssueFinalCertificate(_ context.Context, precert *ca.CodeSigningPreCertificate, scts ...*ct.SignedCertificateTimestamp) (*ca.CodeSigningCertificate, error) {
 	// remove poison extension from precertificate.
 	var exts []pkix.Extension
 	for _, ext := range precert.PreCert.Extensions {
@@ -106,8 +107,15 @@ func (bca *BaseCA) IssueFinalCertificate(_ context.Context, precert *ca.CodeSign
 			exts = append(exts, ext)
 		}
 	}
-	// append SCT extension. Supports multiple SCTs, but Fulcio only writes to one log currently.
-	sctExt, err := generateSCTListExt([]ct.SignedCertificateTimestamp{*sct})
+	if len(scts) == 0 {
+		return nil, errors.New("no SCTs to embed in the final certificate")
+	}
+	// append SCT extension with an SCT from each log
+	sctList := make([]ct.SignedCertificateTimestamp, 0, len(scts))
+	for _, sct := range scts {
+		sctList = append(sctList, *sct)
+	}
+	sctExt, err := generateSCTListExt(sctList)
 	if err != nil {
 		return nil, err
 	}
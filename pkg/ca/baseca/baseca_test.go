@@ -26,6 +26,7 @@ import (
 	"testing"
 
 	ct "github.com/google/certificate-transparency-go"
+	ctx509 "github.com/google/certificate-transparency-go/x509"
 	"github.com/sigstore/fulcio/pkg/ca"
 	"github.com/sigstore/fulcio/pkg/certificate"
 	"github.com/sigstore/fulcio/pkg/test"
@@ -151,3 +152,35 @@ func TestCreatePrecertificateAndIssueFinalCertificate(t *testing.T) {
 		t.Fatal("expected SCT extension to be in certificate")
 	}
 }
+
+func TestIssueFinalCertificateWithMultipleSCTs(t *testing.T) {
+	rootCert, rootKey, _ := test.GenerateRootCA()
+	subCert, subKey, _ := test.GenerateSubordinateCA(rootCert, rootKey)
+	priv, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	bca := BaseCA{
+		SignerWithChain: &ca.SignerCerts{Certs: []*x509.Certificate{subCert, rootCert}, Signer: subKey},
+	}
+	precsc, err := bca.CreatePrecertificate(context.TODO(), testPrincipal{}, priv.Public())
+	if err != nil {
+		t.Fatalf("error generating precertificate: %v", err)
+	}
+
+	if _, err := bca.IssueFinalCertificate(context.TODO(), precsc); err == nil {
+		t.Fatal("expected error issuing certificate without SCTs")
+	}
+
+	csc, err := bca.IssueFinalCertificate(context.TODO(), precsc,
+		&ct.SignedCertificateTimestamp{LogID: ct.LogID{KeyID: [32]byte{1}}},
+		&ct.SignedCertificateTimestamp{LogID: ct.LogID{KeyID: [32]byte{2}}})
+	if err != nil {
+		t.Fatalf("error issuing certificate: %v", err)
+	}
+	cert, err := ctx509.ParseCertificate(csc.FinalCertificate.Raw)
+	if err != nil {
+		t.Fatalf("error parsing certificate: %v", err)
+	}
+	if len(cert.SCTList.SCTList) != 2 {
+		t.Fatalf("got %d embedded SCTs, wanted 2", len(cert.SCTList.SCTList))
+	}
+}
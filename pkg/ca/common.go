@@ -26,6 +26,35 @@ import (
 	"github.com/sigstore/sigstore/pkg/cryptoutils"
 )
 
+// DefaultValidity is the validity of issued certificates when none is set
+// on the request context.
+var DefaultValidity = Validity{Lifetime: 10 * time.Minute}
+
+// Validity describes the validity period of an issued certificate. The
+// certificate is valid from Backdate before issuance until Lifetime after
+// issuance.
+type Validity struct {
+	Lifetime time.Duration
+	Backdate time.Duration
+}
+
+type validityKey struct{}
+
+// WithValidity returns a context that requests certificates created with it
+// to have the given validity.
+func WithValidity(ctx context.Context, v Validity) context.Context {
+	return context.WithValue(ctx, validityKey{}, v)
+}
+
+// ValidityFromContext returns the validity set on the context, or
+// DefaultValidity if none was set.
+func ValidityFromContext(ctx context.Context) Validity {
+	if v, ok := ctx.Value(validityKey{}).(Validity); ok {
+		return v
+	}
+	return DefaultValidity
+}
+
 func MakeX509(ctx context.Context, principal identity.Principal, publicKey crypto.PublicKey) (*x509.Certificate, error) {
 	serialNumber, err := cryptoutils.GenerateSerialNumber()
 	if err != nil {
@@ -37,10 +66,12 @@ func MakeX509(ctx context.Context, principal identity.Principal, publicKey crypt
 		return nil, err
 	}
 
+	validity := ValidityFromContext(ctx)
+	now := time.Now()
 	cert := &x509.Certificate{
 		SerialNumber: serialNumber,
-		NotBefore:    time.Now(),
-		NotAfter:     time.Now().Add(time.Minute * 10),
+		NotBefore:    now.Add(-validity.Backdate),
+		NotAfter:     now.Add(validity.Lifetime),
 		SubjectKeyId: skid,
 		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
 		KeyUsage:     x509.KeyUsageDigitalSignature,
@@ -70,6 +70,24 @@ func TestMakeX509(t *testing.T) {
 	}
 }
 
+func TestMakeX509WithValidity(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error generating key: %v", err)
+	}
+	ctx := WithValidity(context.TODO(), Validity{Lifetime: time.Hour, Backdate: time.Minute})
+	cert, err := MakeX509(ctx, &testPrincipal{}, key.Public())
+	if err != nil {
+		t.Fatalf("unexpected error calling MakeX509: %v", err)
+	}
+	if got := cert.NotAfter.Sub(cert.NotBefore); got != time.Hour+time.Minute {
+		t.Fatalf("expected validity of 61 minutes, got %v", got)
+	}
+	if cert.NotBefore.After(time.Now().Add(-59 * time.Second)) {
+		t.Fatalf("expected certificate to be backdated by a minute, got NotBefore %v", cert.NotBefore)
+	}
+}
+
 func TestVerifyCertChain(t *testing.T) {
 	rootCert, rootKey, _ := test.GenerateRootCA()
 	subCert, subKey, _ := test.GenerateSubordinateCA(rootCert, rootKey)
@@ -23,8 +23,9 @@ import (
 	"github.com/sigstore/fulcio/pkg/identity"
 )
 
-// EmbeddedSCTCA implements precertificate and certificate issuance. Certificates will contain an embedded SCT.
+// EmbeddedSCTCA implements precertificate and certificate issuance. Certificates will contain embedded SCTs,
+// one from each CT log the precertificate was submitted to.
 type EmbeddedSCTCA interface {
 	CreatePrecertificate(context.Context, identity.Principal, crypto.PublicKey) (*CodeSigningPreCertificate, error)
-	IssueFinalCertificate(ctx context.Context, precert *CodeSigningPreCertificate, sct *ct.SignedCertificateTimestamp) (*CodeSigningCertificate, error)
+	IssueFinalCertificate(ctx context.Context, precert *CodeSigningPreCertificate, scts ...*ct.SignedCertificateTimestamp) (*CodeSigningCertificate, error)
 }
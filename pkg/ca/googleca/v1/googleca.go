@@ -32,6 +32,7 @@ import (
 	"cloud.google.com/go/security/privateca/apiv1/privatecapb"
 	"github.com/sigstore/fulcio/pkg/ca"
 	"github.com/sigstore/fulcio/pkg/identity"
+	"github.com/sigstore/fulcio/pkg/log"
 	"github.com/sigstore/sigstore/pkg/cryptoutils"
 	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
@@ -47,6 +48,8 @@ type CertAuthorityService struct {
 	// protected by once
 	cachedRoots     [][]*x509.Certificate
 	cachedRootsOnce sync.Once
+
+	backdateWarning sync.Once
 }
 
 func NewCertAuthorityService(ctx context.Context, parent string, opts ...option.ClientOption) (ca.CertificateAuthority, error) {
@@ -240,6 +243,12 @@ func (c *CertAuthorityService) CreateCertificate(ctx context.Context, principal
 	if err != nil {
 		return nil, ca.ValidationError(err)
 	}
+	// CA Service starts the validity of certificates when they are issued
+	if ca.ValidityFromContext(ctx).Backdate > 0 {
+		c.backdateWarning.Do(func() {
+			log.ContextLogger(ctx).Warn("CertificateBackdate is not supported by Google CA Service, certificates are valid from the time they are issued")
+		})
+	}
 
 	pubKeyBytes, err := cryptoutils.MarshalPublicKeyToPEM(publicKey)
 	if err != nil {
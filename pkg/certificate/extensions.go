@@ -437,3 +437,84 @@ func ParseDERString(val []byte, parsedVal *string) error {
 	}
 	return nil
 }
+
+// extensionNames are the names of the Fulcio extensions, as documented at
+// docs/oid-info.md, and the fields of Extensions setting them. Deprecated
+// extensions have raw string values, all others are DER-encoded strings.
+var extensionNames = []struct {
+	oid        asn1.ObjectIdentifier
+	name       string
+	deprecated bool
+	field      func(Extensions) string
+}{
+	{OIDIssuer, "Issuer (deprecated)", true, func(e Extensions) string { return e.Issuer }},
+	{OIDGitHubWorkflowTrigger, "GitHub Workflow Trigger (deprecated)", true, func(e Extensions) string { return e.GithubWorkflowTrigger }},
+	{OIDGitHubWorkflowSHA, "GitHub Workflow SHA (deprecated)", true, func(e Extensions) string { return e.GithubWorkflowSHA }},
+	{OIDGitHubWorkflowName, "GitHub Workflow Name (deprecated)", true, func(e Extensions) string { return e.GithubWorkflowName }},
+	{OIDGitHubWorkflowRepository, "GitHub Workflow Repository (deprecated)", true, func(e Extensions) string { return e.GithubWorkflowRepository }},
+	{OIDGitHubWorkflowRef, "GitHub Workflow Ref (deprecated)", true, func(e Extensions) string { return e.GithubWorkflowRef }},
+	{OIDIssuerV2, "Issuer (V2)", false, func(e Extensions) string { return e.Issuer }},
+	{OIDBuildSignerURI, "Build Signer URI", false, func(e Extensions) string { return e.BuildSignerURI }},
+	{OIDBuildSignerDigest, "Build Signer Digest", false, func(e Extensions) string { return e.BuildSignerDigest }},
+	{OIDRunnerEnvironment, "Runner Environment", false, func(e Extensions) string { return e.RunnerEnvironment }},
+	{OIDSourceRepositoryURI, "Source Repository URI", false, func(e Extensions) string { return e.SourceRepositoryURI }},
+	{OIDSourceRepositoryDigest, "Source Repository Digest", false, func(e Extensions) string { return e.SourceRepositoryDigest }},
+	{OIDSourceRepositoryRef, "Source Repository Ref", false, func(e Extensions) string { return e.SourceRepositoryRef }},
+	{OIDSourceRepositoryIdentifier, "Source Repository Identifier", false, func(e Extensions) string { return e.SourceRepositoryIdentifier }},
+	{OIDSourceRepositoryOwnerURI, "Source Repository Owner URI", false, func(e Extensions) string { return e.SourceRepositoryOwnerURI }},
+	{OIDSourceRepositoryOwnerIdentifier, "Source Repository Owner Identifier", false, func(e Extensions) string { return e.SourceRepositoryOwnerIdentifier }},
+	{OIDBuildConfigURI, "Build Config URI", false, func(e Extensions) string { return e.BuildConfigURI }},
+	{OIDBuildConfigDigest, "Build Config Digest", false, func(e Extensions) string { return e.BuildConfigDigest }},
+	{OIDBuildTrigger, "Build Trigger", false, func(e Extensions) string { return e.BuildTrigger }},
+	{OIDRunInvocationURI, "Run Invocation URI", false, func(e Extensions) string { return e.RunInvocationURI }},
+	{OIDSourceRepositoryVisibilityAtSigning, "Source Repository Visibility At Signing", false, func(e Extensions) string { return e.SourceRepositoryVisibilityAtSigning }},
+}
+
+// ExtensionField is the value of an extension set in Extensions.
+type ExtensionField struct {
+	OID   asn1.ObjectIdentifier
+	Name  string
+	Value string
+}
+
+// Fields returns the extensions with a value set in e, in OID order. Unlike
+// Render, the issuer need not be set and values are not encoded, so it can
+// describe extension templates.
+func (e Extensions) Fields() []ExtensionField {
+	var fields []ExtensionField
+	for _, ext := range extensionNames {
+		if value := ext.field(e); value != "" {
+			fields = append(fields, ExtensionField{OID: ext.oid, Name: ext.name, Value: value})
+		}
+	}
+	return fields
+}
+
+// ExtensionName returns the name of the Fulcio extension with the given OID,
+// or the empty string if it isn't a Fulcio extension.
+func ExtensionName(oid asn1.ObjectIdentifier) string {
+	for _, e := range extensionNames {
+		if oid.Equal(e.oid) {
+			return e.name
+		}
+	}
+	return ""
+}
+
+// ExtensionValue decodes the string value of a Fulcio extension.
+func ExtensionValue(ext pkix.Extension) (string, error) {
+	for _, e := range extensionNames {
+		if !ext.Id.Equal(e.oid) {
+			continue
+		}
+		if e.deprecated {
+			return string(ext.Value), nil
+		}
+		var value string
+		if err := ParseDERString(ext.Value, &value); err != nil {
+			return "", err
+		}
+		return value, nil
+	}
+	return "", fmt.Errorf("%v is not a Fulcio extension", ext.Id)
+}
@@ -193,3 +193,58 @@ func TestParseDERString(t *testing.T) {
 		t.Errorf("unexpected result: got %q, want %q", actual, expected)
 	}
 }
+
+func TestExtensionNameAndValue(t *testing.T) {
+	exts, err := Extensions{
+		Issuer:            "https://token.actions.githubusercontent.com",
+		GithubWorkflowSHA: "abc123",
+		BuildSignerURI:    "https://github.com/foo/bar/.github/workflows/release.yml@refs/heads/main",
+	}.Render()
+	if err != nil {
+		t.Fatalf("Render() = %v", err)
+	}
+	want := map[string][2]string{
+		"1.3.6.1.4.1.57264.1.1": {"Issuer (deprecated)", "https://token.actions.githubusercontent.com"},
+		"1.3.6.1.4.1.57264.1.3": {"GitHub Workflow SHA (deprecated)", "abc123"},
+		"1.3.6.1.4.1.57264.1.8": {"Issuer (V2)", "https://token.actions.githubusercontent.com"},
+		"1.3.6.1.4.1.57264.1.9": {"Build Signer URI", "https://github.com/foo/bar/.github/workflows/release.yml@refs/heads/main"},
+	}
+	if len(exts) != len(want) {
+		t.Fatalf("got %d extensions, wanted %d", len(exts), len(want))
+	}
+	for _, ext := range exts {
+		value, err := ExtensionValue(ext)
+		if err != nil {
+			t.Fatalf("ExtensionValue(%v) = %v", ext.Id, err)
+		}
+		got := [2]string{ExtensionName(ext.Id), value}
+		if got != want[ext.Id.String()] {
+			t.Errorf("extension %v: got %q, wanted %q", ext.Id, got, want[ext.Id.String()])
+		}
+	}
+
+	unknown := pkix.Extension{Id: asn1.ObjectIdentifier{2, 5, 29, 17}}
+	if name := ExtensionName(unknown.Id); name != "" {
+		t.Errorf("ExtensionName(%v) = %q, wanted empty", unknown.Id, name)
+	}
+	if _, err := ExtensionValue(unknown); err == nil {
+		t.Errorf("ExtensionValue(%v) should fail for a non-Fulcio extension", unknown.Id)
+	}
+}
+
+func TestExtensionFields(t *testing.T) {
+	templates := Extensions{
+		BuildSignerURI:    "{{ .url }}/{{ .job_workflow_ref }}",
+		RunnerEnvironment: "runner_environment",
+	}
+	want := []ExtensionField{
+		{OID: OIDBuildSignerURI, Name: "Build Signer URI", Value: "{{ .url }}/{{ .job_workflow_ref }}"},
+		{OID: OIDRunnerEnvironment, Name: "Runner Environment", Value: "runner_environment"},
+	}
+	if diff := cmp.Diff(want, templates.Fields()); diff != "" {
+		t.Errorf("Fields() mismatch (-want +got):\n%s", diff)
+	}
+	if fields := (Extensions{}).Fields(); len(fields) != 0 {
+		t.Errorf("Fields() of empty extensions = %v", fields)
+	}
+}
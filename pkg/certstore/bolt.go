@@ -0,0 +1,206 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package certstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	certificatesBucket = []byte("certificates")
+	// issued indexes serial numbers by issuance time
+	issuedBucket = []byte("issued")
+	// identities indexes serial numbers by identity and issuance time
+	identitiesBucket = []byte("identities")
+)
+
+// BoltStore is a Store backed by an embedded bbolt database file.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+var _ Store = (*BoltStore)(nil)
+
+// NewBoltStore opens the database at path, creating it if it doesn't exist.
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("opening certificate store: %w", err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{certificatesBucket, issuedBucket, identitiesBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("creating certificate store buckets: %w", err)
+	}
+	return &BoltStore{db: db}, nil
+}
+
+// timeKey encodes t so that keys sort in time order. Times before the Unix
+// epoch, including the zero time, sort first.
+func timeKey(t time.Time) []byte {
+	key := make([]byte, 8)
+	if t.After(time.Unix(0, 0)) {
+		binary.BigEndian.PutUint64(key, uint64(t.UnixNano()))
+	}
+	return key
+}
+
+func identityPrefix(identity string) []byte {
+	return append([]byte(identity), 0)
+}
+
+// identities returns the distinct identities a record is indexed by.
+func identities(record *Record) []string {
+	seen := map[string]bool{}
+	var ids []string
+	for _, id := range append([]string{record.Identity}, record.SubjectAlternativeNames...) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (s *BoltStore) Put(_ context.Context, record *Record) error {
+	if record.Serial == "" {
+		return errors.New("certificate record is missing a serial number")
+	}
+	value, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	serial := []byte(record.Serial)
+	issued := timeKey(record.IssuedAt)
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(certificatesBucket).Put(serial, value); err != nil {
+			return err
+		}
+		if err := tx.Bucket(issuedBucket).Put(append(issued, serial...), nil); err != nil {
+			return err
+		}
+		for _, id := range identities(record) {
+			key := append(append(identityPrefix(id), issued...), serial...)
+			if err := tx.Bucket(identitiesBucket).Put(key, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *BoltStore) Get(_ context.Context, serial string) (*Record, error) {
+	var record *Record
+	err := s.db.View(func(tx *bolt.Tx) error {
+		var err error
+		record, err = get(tx, []byte(serial))
+		return err
+	})
+	return record, err
+}
+
+func get(tx *bolt.Tx, serial []byte) (*Record, error) {
+	value := tx.Bucket(certificatesBucket).Get(serial)
+	if value == nil {
+		return nil, ErrNotFound
+	}
+	record := &Record{}
+	if err := json.Unmarshal(value, record); err != nil {
+		return nil, fmt.Errorf("unmarshalling certificate record: %w", err)
+	}
+	return record, nil
+}
+
+func (s *BoltStore) Search(_ context.Context, query Query) ([]*Record, string, error) {
+	bucket, prefix := issuedBucket, []byte{}
+	if query.Identity != "" {
+		bucket, prefix = identitiesBucket, identityPrefix(query.Identity)
+	}
+	start := append(bytes.Clone(prefix), timeKey(query.Start)...)
+	var end []byte
+	if !query.End.IsZero() {
+		end = append(bytes.Clone(prefix), timeKey(query.End)...)
+	}
+
+	var after []byte
+	if query.Cursor != "" {
+		var err error
+		after, err = base64.RawURLEncoding.DecodeString(query.Cursor)
+		if err != nil || !bytes.HasPrefix(after, prefix) || len(after) < len(prefix)+8 {
+			return nil, "", ErrInvalidCursor
+		}
+		if bytes.Compare(after, start) > 0 {
+			start = after
+		}
+	}
+
+	// inRange reports whether key belongs to the queried identity and time range
+	inRange := func(key []byte) bool {
+		return key != nil && bytes.HasPrefix(key, prefix) && (end == nil || bytes.Compare(key, end) < 0)
+	}
+
+	var (
+		records []*Record
+		cursor  string
+	)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucket).Cursor()
+		for key, _ := c.Seek(start); inRange(key); key, _ = c.Next() {
+			if bytes.Equal(key, after) {
+				continue
+			}
+			record, err := get(tx, key[len(prefix)+8:])
+			if err != nil {
+				return err
+			}
+			if query.Issuer != "" && record.Issuer != query.Issuer {
+				continue
+			}
+			records = append(records, record)
+			if query.Limit > 0 && len(records) == query.Limit {
+				if next, _ := c.Next(); inRange(next) {
+					cursor = base64.RawURLEncoding.EncodeToString(key)
+				}
+				return nil
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return records, cursor, nil
+}
+
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,187 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestStore(t *testing.T) *BoltStore {
+	t.Helper()
+	s, err := NewBoltStore(filepath.Join(t.TempDir(), "certs.db"))
+	if err != nil {
+		t.Fatalf("NewBoltStore() = %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func serials(records []*Record) []string {
+	var s []string
+	for _, r := range records {
+		s = append(s, r.Serial)
+	}
+	return s
+}
+
+func TestBoltStoreGet(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	want := &Record{
+		Serial:                  "1234",
+		Issuer:                  "https://accounts.example.com",
+		Identity:                "foo@example.com",
+		SubjectAlternativeNames: []string{"foo@example.com"},
+		IssuedAt:                time.Unix(100, 0).UTC(),
+		Chain:                   []string{"leaf", "root"},
+	}
+	if err := s.Put(ctx, want); err != nil {
+		t.Fatalf("Put() = %v", err)
+	}
+	got, err := s.Get(ctx, "1234")
+	if err != nil {
+		t.Fatalf("Get() = %v", err)
+	}
+	if got.Identity != want.Identity || got.Issuer != want.Issuer || !got.IssuedAt.Equal(want.IssuedAt) || len(got.Chain) != 2 {
+		t.Errorf("Get() = %+v, wanted %+v", got, want)
+	}
+
+	if _, err := s.Get(ctx, "5678"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if err := s.Put(ctx, &Record{}); err == nil {
+		t.Error("expected error storing record without serial")
+	}
+}
+
+func TestBoltStoreSearch(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	workflow := "https://github.com/foo/bar/.github/workflows/release.yml@refs/heads/main"
+	for i := 0; i < 10; i++ {
+		record := &Record{
+			Serial:   fmt.Sprint(i),
+			Issuer:   "https://token.actions.githubusercontent.com",
+			Identity: "repo:foo/bar:ref:refs/heads/main",
+			IssuedAt: time.Unix(int64(i*60), 0),
+		}
+		switch {
+		case i%2 == 0:
+			record.SubjectAlternativeNames = []string{workflow}
+		case i == 9:
+			record.Issuer = "https://accounts.example.com"
+			record.Identity = "foo@example.com"
+			record.SubjectAlternativeNames = []string{"foo@example.com"}
+		default:
+			record.SubjectAlternativeNames = []string{"https://github.com/foo/bar/.github/workflows/test.yml@refs/heads/main"}
+		}
+		if err := s.Put(ctx, record); err != nil {
+			t.Fatalf("Put() = %v", err)
+		}
+	}
+
+	tests := map[string]struct {
+		query Query
+		want  []string
+	}{
+		"everything": {
+			want: []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"},
+		},
+		"by subject alternative name": {
+			query: Query{Identity: workflow},
+			want:  []string{"0", "2", "4", "6", "8"},
+		},
+		"by principal": {
+			query: Query{Identity: "foo@example.com"},
+			want:  []string{"9"},
+		},
+		"by issuer": {
+			query: Query{Issuer: "https://accounts.example.com"},
+			want:  []string{"9"},
+		},
+		"by time range": {
+			query: Query{Start: time.Unix(120, 0), End: time.Unix(300, 0)},
+			want:  []string{"2", "3", "4"},
+		},
+		"by identity and time range": {
+			query: Query{Identity: workflow, Start: time.Unix(120, 0), End: time.Unix(300, 0)},
+			want:  []string{"2", "4"},
+		},
+		"unknown identity": {
+			query: Query{Identity: "bar@example.com"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			records, cursor, err := s.Search(ctx, test.query)
+			if err != nil {
+				t.Fatalf("Search() = %v", err)
+			}
+			if fmt.Sprint(serials(records)) != fmt.Sprint(test.want) {
+				t.Errorf("Search() = %v, wanted %v", serials(records), test.want)
+			}
+			if cursor != "" {
+				t.Errorf("expected no cursor, got %q", cursor)
+			}
+		})
+	}
+}
+
+func TestBoltStoreSearchPages(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		if err := s.Put(ctx, &Record{
+			Serial:   fmt.Sprint(i),
+			Identity: "foo@example.com",
+			IssuedAt: time.Unix(int64(i), 0),
+		}); err != nil {
+			t.Fatalf("Put() = %v", err)
+		}
+	}
+
+	var (
+		got    []string
+		cursor string
+		pages  int
+	)
+	for {
+		records, next, err := s.Search(ctx, Query{Identity: "foo@example.com", Limit: 2, Cursor: cursor})
+		if err != nil {
+			t.Fatalf("Search() = %v", err)
+		}
+		pages++
+		got = append(got, serials(records)...)
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	if fmt.Sprint(got) != "[0 1 2 3 4]" || pages != 3 {
+		t.Errorf("got %v in %d pages, wanted [0 1 2 3 4] in 3 pages", got, pages)
+	}
+
+	if _, _, err := s.Search(ctx, Query{Cursor: "not a cursor"}); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("expected ErrInvalidCursor, got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package certstore records issued certificates so that they can be looked up
+// by serial number or by the identity they were issued to.
+package certstore
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned when no certificate has the requested serial number.
+	ErrNotFound = errors.New("certificate not found")
+	// ErrInvalidCursor is returned when a search cursor is malformed or was
+	// returned by a search for a different identity.
+	ErrInvalidCursor = errors.New("invalid search cursor")
+)
+
+// Record is an issued certificate along with the identity it was issued to.
+type Record struct {
+	// Decimal serial number of the certificate
+	Serial string `json:"serial"`
+	// OIDC issuer of the token the certificate was issued for
+	Issuer string `json:"issuer"`
+	// Name of the authenticated principal, such as the token subject
+	Identity                string    `json:"identity"`
+	SubjectAlternativeNames []string  `json:"subjectAlternativeNames,omitempty"`
+	NotBefore               time.Time `json:"notBefore"`
+	NotAfter                time.Time `json:"notAfter"`
+	IssuedAt                time.Time `json:"issuedAt"`
+	// PEM-encoded certificate followed by its chain up to the root
+	Chain []string `json:"chain"`
+}
+
+// Query selects issued certificates. Empty fields match every certificate.
+type Query struct {
+	// Matches the principal name or any subject alternative name
+	Identity string
+	Issuer   string
+	// Issuance time range, inclusive of Start and exclusive of End
+	Start time.Time
+	End   time.Time
+	// Maximum number of records to return
+	Limit int
+	// Cursor returned by a previous search to continue from
+	Cursor string
+}
+
+// Store persists issued certificates. Implementations must be safe for
+// concurrent use.
+type Store interface {
+	Put(ctx context.Context, record *Record) error
+	// Get returns ErrNotFound if there is no certificate with the serial number.
+	Get(ctx context.Context, serial string) (*Record, error)
+	// Search returns matching certificates in order of issuance, and a cursor
+	// to continue the search from if the limit was reached.
+	Search(ctx context.Context, query Query) ([]*Record, string, error)
+	Close() error
+}
@@ -19,6 +19,9 @@ import (
 	"bytes"
 	"context"
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
 	"crypto/x509"
 	"errors"
 	"fmt"
@@ -39,12 +42,58 @@ import (
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/sigstore/sigstore/pkg/cryptoutils"
 	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+)
+
+// SignatureAlgorithm is the signature scheme of a challenge.
+type SignatureAlgorithm int
+
+const (
+	// SignatureAlgorithmDefault selects the scheme from the type of the key,
+	// using PKCS#1 v1.5 for RSA keys
+	SignatureAlgorithmDefault SignatureAlgorithm = iota
+	SignatureAlgorithmRSAPSS
+	SignatureAlgorithmECDSA
+	SignatureAlgorithmED25519
 )
 
 // CheckSignature verifies a challenge, a signature over the subject or email
-// of an OIDC token
+// of an OIDC token, created with SHA-256 and the default scheme for the key
 func CheckSignature(pub crypto.PublicKey, proof []byte, subject string) error {
-	verifier, err := signature.LoadVerifier(pub, crypto.SHA256)
+	return CheckSignatureWithAlgorithm(pub, proof, subject, SignatureAlgorithmDefault, crypto.SHA256)
+}
+
+// CheckSignatureWithAlgorithm verifies a challenge created with the given
+// scheme and hash function. The scheme must match the type of the key, and
+// the hash function is ignored for Ed25519 keys.
+func CheckSignatureWithAlgorithm(pub crypto.PublicKey, proof []byte, subject string, alg SignatureAlgorithm, hash crypto.Hash) error {
+	switch hash {
+	case crypto.SHA256, crypto.SHA384, crypto.SHA512:
+	default:
+		return fmt.Errorf("unsupported hash function %v", hash)
+	}
+	opts := []signature.LoadOption{options.WithHash(hash)}
+
+	switch alg {
+	case SignatureAlgorithmDefault:
+	case SignatureAlgorithmRSAPSS:
+		if _, ok := pub.(*rsa.PublicKey); !ok {
+			return errors.New("RSA-PSS signatures require an RSA public key")
+		}
+		opts = append(opts, options.WithRSAPSS(&rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto}))
+	case SignatureAlgorithmECDSA:
+		if _, ok := pub.(*ecdsa.PublicKey); !ok {
+			return errors.New("ECDSA signatures require an ECDSA public key")
+		}
+	case SignatureAlgorithmED25519:
+		if _, ok := pub.(ed25519.PublicKey); !ok {
+			return errors.New("ed25519 signatures require an ed25519 public key")
+		}
+	default:
+		return fmt.Errorf("unsupported signature algorithm %d", alg)
+	}
+
+	verifier, err := signature.LoadVerifierWithOpts(pub, opts...)
 	if err != nil {
 		return err
 	}
@@ -22,6 +22,7 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
+	"crypto/sha512"
 	"testing"
 
 	"github.com/sigstore/sigstore/pkg/cryptoutils"
@@ -85,6 +86,54 @@ func TestCheckSignatureRSA(t *testing.T) {
 	}
 }
 
+func TestCheckSignatureRSAPSS(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	failErr(t, err)
+
+	email := "test@gmail.com"
+	h := sha256.Sum256([]byte(email))
+	signature, err := rsa.SignPSS(rand.Reader, priv, crypto.SHA256, h[:], nil)
+	failErr(t, err)
+
+	if err := CheckSignatureWithAlgorithm(&priv.PublicKey, signature, email, SignatureAlgorithmRSAPSS, crypto.SHA256); err != nil {
+		t.Fatal(err)
+	}
+	// PSS signatures are not PKCS#1 v1.5 signatures
+	if err := CheckSignature(&priv.PublicKey, signature, email); err == nil {
+		t.Fatal("check should have failed")
+	}
+
+	// RSA-PSS requires an RSA key
+	ecPriv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	failErr(t, err)
+	if err := CheckSignatureWithAlgorithm(&ecPriv.PublicKey, signature, email, SignatureAlgorithmRSAPSS, crypto.SHA256); err == nil {
+		t.Fatal("check should have failed")
+	}
+}
+
+func TestCheckSignatureHash(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	failErr(t, err)
+
+	email := "test@gmail.com"
+	h := sha512.Sum384([]byte(email))
+	signature, err := ecdsa.SignASN1(rand.Reader, priv, h[:])
+	failErr(t, err)
+
+	if err := CheckSignatureWithAlgorithm(&priv.PublicKey, signature, email, SignatureAlgorithmECDSA, crypto.SHA384); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckSignature(&priv.PublicKey, signature, email); err == nil {
+		t.Fatal("check with SHA-256 should have failed")
+	}
+	if err := CheckSignatureWithAlgorithm(&priv.PublicKey, signature, email, SignatureAlgorithmECDSA, crypto.SHA1); err == nil {
+		t.Fatal("SHA-1 should not be supported")
+	}
+	if err := CheckSignatureWithAlgorithm(&priv.PublicKey, signature, email, SignatureAlgorithmED25519, crypto.SHA384); err == nil {
+		t.Fatal("check with mismatched algorithm should have failed")
+	}
+}
+
 func TestParsePublicKey(t *testing.T) {
 	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	failErr(t, err)
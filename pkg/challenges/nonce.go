@@ -0,0 +1,149 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package challenges
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sigstore/fulcio/pkg/identity"
+)
+
+// DefaultNonceLifetime is how long nonces are accepted unless configured
+// otherwise.
+const DefaultNonceLifetime = 5 * time.Minute
+
+const (
+	nonceRandomSize = 16
+	nonceMinKeySize = 32
+	nonceSize       = nonceRandomSize + 8 + sha256.Size
+)
+
+var (
+	// ErrInvalidNonce is returned for nonces that were not issued for the
+	// identity, or not by this NonceIssuer.
+	ErrInvalidNonce = errors.New("invalid challenge nonce")
+	// ErrNonceExpired is returned for nonces past their expiration.
+	ErrNonceExpired = errors.New("challenge nonce has expired")
+	// ErrNonceReplayed is returned by Consume for nonces that were already
+	// used.
+	ErrNonceReplayed = errors.New("challenge nonce has already been used")
+)
+
+// NonceIssuer issues nonces that clients sign as proof of possession instead
+// of their identity. A nonce is bound to the issuer and subject of the
+// identity and expires shortly after it is issued, so that a captured proof
+// can't be reused with a stolen token. Nonces are authenticated with an HMAC
+// key rather than stored, so instances sharing the key accept each other's
+// nonces. Used nonces are recorded until they expire, so that each is only
+// accepted once.
+type NonceIssuer struct {
+	key      []byte
+	lifetime time.Duration
+	store    identity.ReplayStore
+	now      func() time.Time
+}
+
+// NewNonceIssuer returns a NonceIssuer authenticating nonces with key, which
+// must be at least 32 bytes, and recording used nonces in store. If key is
+// nil, a random key is generated.
+func NewNonceIssuer(key []byte, lifetime time.Duration, store identity.ReplayStore) (*NonceIssuer, error) {
+	if key == nil {
+		key = make([]byte, nonceMinKeySize)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("generating challenge key: %w", err)
+		}
+	}
+	if len(key) < nonceMinKeySize {
+		return nil, fmt.Errorf("challenge key must be at least %d bytes", nonceMinKeySize)
+	}
+	if lifetime <= 0 {
+		return nil, errors.New("challenge lifetime must be positive")
+	}
+	if store == nil {
+		return nil, errors.New("challenge replay store must be set")
+	}
+	return &NonceIssuer{key: key, lifetime: lifetime, store: store, now: time.Now}, nil
+}
+
+// Issue returns a new nonce for the identity, and its expiration.
+func (n *NonceIssuer) Issue(issuer, subject string) (string, time.Time, error) {
+	b := make([]byte, nonceRandomSize+8, nonceSize)
+	if _, err := rand.Read(b[:nonceRandomSize]); err != nil {
+		return "", time.Time{}, fmt.Errorf("generating nonce: %w", err)
+	}
+	expiration := n.now().Add(n.lifetime).Truncate(time.Second)
+	binary.BigEndian.PutUint64(b[nonceRandomSize:], uint64(expiration.Unix()))
+	b = append(b, n.mac(issuer, subject, b)...)
+	return base64.RawURLEncoding.EncodeToString(b), expiration, nil
+}
+
+// Verify checks that nonce was issued by n for the identity and has not
+// expired. It does not check whether the nonce was already used.
+func (n *NonceIssuer) Verify(nonce, issuer, subject string) error {
+	b, err := base64.RawURLEncoding.DecodeString(nonce)
+	if err != nil || len(b) != nonceSize {
+		return ErrInvalidNonce
+	}
+	body, mac := b[:nonceRandomSize+8], b[nonceRandomSize+8:]
+	if !hmac.Equal(mac, n.mac(issuer, subject, body)) {
+		return ErrInvalidNonce
+	}
+	if expiration := nonceExpiration(body); n.now().After(expiration) {
+		return fmt.Errorf("%w at %v", ErrNonceExpired, expiration)
+	}
+	return nil
+}
+
+// Consume records a verified nonce as used until it expires, and returns
+// ErrNonceReplayed if it was already used.
+func (n *NonceIssuer) Consume(ctx context.Context, nonce string) error {
+	b, err := base64.RawURLEncoding.DecodeString(nonce)
+	if err != nil || len(b) != nonceSize {
+		return ErrInvalidNonce
+	}
+	fresh, err := n.store.Add(ctx, "nonce:"+nonce, nonceExpiration(b))
+	if err != nil {
+		return fmt.Errorf("checking for nonce replay: %w", err)
+	}
+	if !fresh {
+		return ErrNonceReplayed
+	}
+	return nil
+}
+
+// nonceExpiration returns the expiration encoded in a decoded nonce.
+func nonceExpiration(b []byte) time.Time {
+	return time.Unix(int64(binary.BigEndian.Uint64(b[nonceRandomSize:nonceRandomSize+8])), 0)
+}
+
+func (n *NonceIssuer) mac(issuer, subject string, body []byte) []byte {
+	h := hmac.New(sha256.New, n.key)
+	// Lengths prevent ambiguity between issuer and subject
+	_ = binary.Write(h, binary.BigEndian, uint32(len(issuer)))
+	h.Write([]byte(issuer))
+	_ = binary.Write(h, binary.BigEndian, uint32(len(subject)))
+	h.Write([]byte(subject))
+	h.Write(body)
+	return h.Sum(nil)
+}
@@ -0,0 +1,141 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package challenges
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sigstore/fulcio/pkg/identity"
+)
+
+func newReplayStore(t *testing.T) identity.ReplayStore {
+	t.Helper()
+	store, err := identity.NewMemoryReplayStore(100)
+	if err != nil {
+		t.Fatalf("NewMemoryReplayStore() = %v", err)
+	}
+	return store
+}
+
+func TestNonceIssuer(t *testing.T) {
+	n, err := NewNonceIssuer(nil, time.Minute, newReplayStore(t))
+	if err != nil {
+		t.Fatalf("NewNonceIssuer() = %v", err)
+	}
+	nonce, expiration, err := n.Issue("https://issuer.example.com", "subject")
+	if err != nil {
+		t.Fatalf("Issue() = %v", err)
+	}
+	if until := time.Until(expiration); until <= 0 || until > time.Minute {
+		t.Errorf("unexpected expiration %v", expiration)
+	}
+	if err := n.Verify(nonce, "https://issuer.example.com", "subject"); err != nil {
+		t.Errorf("Verify() = %v", err)
+	}
+
+	other, err := NewNonceIssuer(nil, time.Minute, newReplayStore(t))
+	if err != nil {
+		t.Fatalf("NewNonceIssuer() = %v", err)
+	}
+	tests := map[string]struct {
+		issuer  *NonceIssuer
+		nonce   string
+		iss     string
+		subject string
+	}{
+		"different subject":  {n, nonce, "https://issuer.example.com", "other"},
+		"different issuer":   {n, nonce, "https://other.example.com", "subject"},
+		"ambiguous identity": {n, nonce, "https://issuer.example.comsub", "ject"},
+		"different key":      {other, nonce, "https://issuer.example.com", "subject"},
+		"malformed":          {n, "not a nonce", "https://issuer.example.com", "subject"},
+		"truncated":          {n, nonce[:len(nonce)-2], "https://issuer.example.com", "subject"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := test.issuer.Verify(test.nonce, test.iss, test.subject); !errors.Is(err, ErrInvalidNonce) {
+				t.Errorf("expected ErrInvalidNonce, got %v", err)
+			}
+		})
+	}
+}
+
+func TestNonceExpired(t *testing.T) {
+	key := make([]byte, 32)
+	n, err := NewNonceIssuer(key, time.Minute, newReplayStore(t))
+	if err != nil {
+		t.Fatalf("NewNonceIssuer() = %v", err)
+	}
+	nonce, _, err := n.Issue("https://issuer.example.com", "subject")
+	if err != nil {
+		t.Fatalf("Issue() = %v", err)
+	}
+	n.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	if err := n.Verify(nonce, "https://issuer.example.com", "subject"); !errors.Is(err, ErrNonceExpired) {
+		t.Errorf("expected ErrNonceExpired, got %v", err)
+	}
+
+	// Instances sharing the key accept each other's nonces
+	shared, err := NewNonceIssuer(key, time.Minute, newReplayStore(t))
+	if err != nil {
+		t.Fatalf("NewNonceIssuer() = %v", err)
+	}
+	if err := shared.Verify(nonce, "https://issuer.example.com", "subject"); err != nil {
+		t.Errorf("Verify() with shared key = %v", err)
+	}
+}
+
+func TestNewNonceIssuerInvalid(t *testing.T) {
+	if _, err := NewNonceIssuer(make([]byte, 16), time.Minute, newReplayStore(t)); err == nil {
+		t.Error("expected error for short key")
+	}
+	if _, err := NewNonceIssuer(nil, 0, newReplayStore(t)); err == nil {
+		t.Error("expected error for zero lifetime")
+	}
+	if _, err := NewNonceIssuer(nil, time.Minute, nil); err == nil {
+		t.Error("expected error for missing replay store")
+	}
+}
+
+func TestNonceConsume(t *testing.T) {
+	key := make([]byte, 32)
+	store := newReplayStore(t)
+	n, err := NewNonceIssuer(key, time.Minute, store)
+	if err != nil {
+		t.Fatalf("NewNonceIssuer() = %v", err)
+	}
+	nonce, _, err := n.Issue("https://issuer.example.com", "subject")
+	if err != nil {
+		t.Fatalf("Issue() = %v", err)
+	}
+	ctx := context.Background()
+	if err := n.Consume(ctx, nonce); err != nil {
+		t.Fatalf("Consume() = %v", err)
+	}
+	if err := n.Consume(ctx, nonce); !errors.Is(err, ErrNonceReplayed) {
+		t.Errorf("expected ErrNonceReplayed, got %v", err)
+	}
+
+	// Instances sharing the key and store reject nonces used by each other
+	shared, err := NewNonceIssuer(key, time.Minute, store)
+	if err != nil {
+		t.Fatalf("NewNonceIssuer() = %v", err)
+	}
+	if err := shared.Consume(ctx, nonce); !errors.Is(err, ErrNonceReplayed) {
+		t.Errorf("expected ErrNonceReplayed from shared store, got %v", err)
+	}
+}
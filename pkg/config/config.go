@@ -36,6 +36,7 @@ import (
 	fulciogrpc "github.com/sigstore/fulcio/pkg/generated/protobuf"
 	"github.com/sigstore/fulcio/pkg/log"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"gopkg.in/yaml.v3"
 )
 
@@ -68,10 +69,24 @@ type FulcioConfig struct {
 	// on the configuration file
 	CIIssuerMetadata map[string]IssuerMetadata `json:"CIIssuerMetadata,omitempty" yaml:"ci-issuer-metadata,omitempty"`
 
+	// ClientCertificateIssuers authenticate callers by the TLS client
+	// certificate presented on the gRPC listener instead of an OIDC token.
+	// They are keyed by the issuer URL recorded in issued certificates.
+	ClientCertificateIssuers map[string]ClientCertificateIssuer `json:"ClientCertificateIssuers,omitempty" yaml:"client-certificate-issuers,omitempty"`
+
 	// verifiers is a fixed mapping from our OIDCIssuers to their OIDC verifiers.
 	verifiers map[string][]*verifierWithConfig
 	// lru is an LRU cache of recently used verifiers for our meta issuers.
 	lru *lru.TwoQueueCache
+	// discoveryErr holds the errors discovering OIDCIssuers without a verifier.
+	discoveryErr error
+}
+
+// DiscoveryErr returns the errors discovering the OIDC issuers when the config
+// was loaded, or nil if every issuer has a verifier. Tokens from an issuer
+// that could not be discovered are rejected.
+func (fc *FulcioConfig) DiscoveryErr() error {
+	return fc.discoveryErr
 }
 
 type IssuerMetadata struct {
@@ -116,6 +131,141 @@ type OIDCIssuer struct {
 	// Optional, the contact for the issuer team
 	// Usually it is a email
 	Contact string `json:"Contact,omitempty" yaml:"contact,omitempty"`
+	// Optional, the lifetime of certificates issued for this issuer's tokens when
+	// the client does not request a validity. Defaults to 10 minutes, or the
+	// maximum lifetime if it is shorter.
+	DefaultCertificateLifetime Duration `json:"DefaultCertificateLifetime,omitempty" yaml:"default-certificate-lifetime,omitempty"`
+	// Optional, the longest lifetime a client may request for a certificate.
+	// Longer requests are clamped to this value. Defaults to the default lifetime.
+	MaxCertificateLifetime Duration `json:"MaxCertificateLifetime,omitempty" yaml:"max-certificate-lifetime,omitempty"`
+	// Optional, how far to backdate the start of a certificate's validity to
+	// tolerate clock skew between Fulcio and verifiers. Defaults to no backdate.
+	// Not supported by the googleca backend, which ignores it.
+	CertificateBackdate Duration `json:"CertificateBackdate,omitempty" yaml:"certificate-backdate,omitempty"`
+	// Optional, limits on the rate at which certificates are issued for this
+	// issuer's tokens. Unlimited if unset.
+	RateLimit *RateLimit `json:"RateLimit,omitempty" yaml:"rate-limit,omitempty"`
+	// Optional, if true each token from this issuer is only accepted once, so
+	// that a leaked token can't be used to request further certificates.
+	// Tokens are identified by their jti claim, or by their hash if it is absent.
+	SingleUseTokens bool `json:"SingleUseTokens,omitempty" yaml:"single-use-tokens,omitempty"`
+	// Optional, if true clients must sign a nonce from GetChallenge as proof of
+	// possession rather than their identity, so that a captured proof can't be
+	// reused with a stolen token.
+	RequireChallenge bool `json:"RequireChallenge,omitempty" yaml:"require-challenge,omitempty"`
+	// Optional, restricts the public keys that certificates are issued for.
+	// Any key that is not weak is allowed if unset.
+	KeyPolicy *KeyPolicy `json:"KeyPolicy,omitempty" yaml:"key-policy,omitempty"`
+	// Optional, a CEL expression over the claims of the token and the
+	// principal that must evaluate to true for a certificate to be issued,
+	// e.g. `claims.repository_owner == "my-org"`. Every identity is allowed if unset.
+	Policy string `json:"Policy,omitempty" yaml:"policy,omitempty"`
+}
+
+// ClientCertificateIssuer accepts SPIFFE X.509-SVIDs as TLS client
+// certificates. The SPIFFE ID of a verified SVID is the subject of the issued
+// certificate, as for 'spiffe' OIDC issuers.
+type ClientCertificateIssuer struct {
+	// The issuer URL recorded in certificates issued to these clients
+	IssuerURL string `json:"IssuerURL,omitempty" yaml:"issuer-url,omitempty"`
+	// Path to a PEM file of the CA certificates that client certificates must
+	// chain to, e.g. the X.509 bundle of a SPIRE trust domain
+	ClientCAPath string `json:"ClientCAPath,omitempty" yaml:"client-ca-path,omitempty"`
+	// The trust domain of the SPIFFE IDs of client certificates
+	SPIFFETrustDomain string `json:"SPIFFETrustDomain,omitempty" yaml:"spiffe-trust-domain,omitempty"`
+	// Optional, a CEL expression over the principal, the SPIFFE ID of the
+	// client certificate, that must evaluate to true for a certificate to be
+	// issued. The claims variable is an empty map. Every identity is allowed
+	// if unset.
+	Policy string `json:"Policy,omitempty" yaml:"policy,omitempty"`
+	// Optional, limits on the rate of certificate issuance to these clients.
+	// Each SPIFFE ID is an identity. Unlimited if unset.
+	RateLimit *RateLimit `json:"RateLimit,omitempty" yaml:"rate-limit,omitempty"`
+}
+
+// RateLimit configures token buckets limiting certificate issuance. A rate of
+// zero is unlimited.
+type RateLimit struct {
+	// Sustained number of certificates per minute issued to each identity
+	IdentityPerMinute float64 `json:"IdentityPerMinute,omitempty" yaml:"identity-per-minute,omitempty"`
+	// Number of certificates an identity may be issued in a burst. Defaults
+	// to the per-minute rate.
+	IdentityBurst int `json:"IdentityBurst,omitempty" yaml:"identity-burst,omitempty"`
+	// Sustained number of certificates per minute issued across all
+	// identities of the issuer
+	IssuerPerMinute float64 `json:"IssuerPerMinute,omitempty" yaml:"issuer-per-minute,omitempty"`
+	// Number of certificates the issuer's identities may be issued in a
+	// burst. Defaults to the per-minute rate.
+	IssuerBurst int `json:"IssuerBurst,omitempty" yaml:"issuer-burst,omitempty"`
+}
+
+// DefaultCertificateLifetime is the lifetime of issued certificates unless
+// configured otherwise for an issuer.
+const DefaultCertificateLifetime = 10 * time.Minute
+
+// CertificateLifetime returns the lifetime of a certificate issued for this
+// issuer's tokens. A requested lifetime of zero selects the issuer's default
+// lifetime, and longer lifetimes than the issuer allows are clamped. Without a
+// configured default, the default is DefaultCertificateLifetime or the
+// issuer's maximum lifetime, whichever is shorter.
+func (iss OIDCIssuer) CertificateLifetime(requested time.Duration) time.Duration {
+	lifetime := DefaultCertificateLifetime
+	if iss.DefaultCertificateLifetime > 0 {
+		lifetime = time.Duration(iss.DefaultCertificateLifetime)
+	}
+	maxLifetime := lifetime
+	if iss.MaxCertificateLifetime > 0 {
+		maxLifetime = time.Duration(iss.MaxCertificateLifetime)
+		lifetime = min(lifetime, maxLifetime)
+	}
+	if requested <= 0 {
+		return lifetime
+	}
+	if requested > maxLifetime {
+		return maxLifetime
+	}
+	return requested
+}
+
+// Duration is a time.Duration that is written in configuration files as a
+// string such as "10m" or "1h30m".
+type Duration time.Duration
+
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("duration must be a string: %w", err)
+	}
+	return d.set(s)
+}
+
+func (d Duration) MarshalYAML() (interface{}, error) {
+	return d.String(), nil
+}
+
+func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
+	var s string
+	if err := value.Decode(&s); err != nil {
+		return fmt.Errorf("duration must be a string: %w", err)
+	}
+	return d.set(s)
+}
+
+func (d *Duration) set(s string) error {
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
 }
 
 func metaRegex(issuer string) (*regexp.Regexp, error) {
@@ -157,6 +307,15 @@ func (fc *FulcioConfig) GetIssuer(issuerURL string) (OIDCIssuer, bool) {
 				IssuerClaim:   iss.IssuerClaim,
 				SubjectDomain: iss.SubjectDomain,
 				CIProvider:    iss.CIProvider,
+
+				DefaultCertificateLifetime: iss.DefaultCertificateLifetime,
+				MaxCertificateLifetime:     iss.MaxCertificateLifetime,
+				CertificateBackdate:        iss.CertificateBackdate,
+				RateLimit:                  iss.RateLimit,
+				SingleUseTokens:            iss.SingleUseTokens,
+				RequireChallenge:           iss.RequireChallenge,
+				KeyPolicy:                  iss.KeyPolicy,
+				Policy:                     iss.Policy,
 			}, true
 		}
 	}
@@ -232,71 +391,96 @@ func (fc *FulcioConfig) ToIssuers() []*fulciogrpc.OIDCIssuer {
 	var issuers []*fulciogrpc.OIDCIssuer
 
 	for _, cfgIss := range fc.OIDCIssuers {
-		issuer := &fulciogrpc.OIDCIssuer{
-			Issuer:            &fulciogrpc.OIDCIssuer_IssuerUrl{IssuerUrl: cfgIss.IssuerURL},
-			Audience:          cfgIss.ClientID,
-			SpiffeTrustDomain: cfgIss.SPIFFETrustDomain,
-			ChallengeClaim:    issuerToChallengeClaim(cfgIss.Type, cfgIss.ChallengeClaim),
-			IssuerType:        cfgIss.Type.String(),
-			SubjectDomain:     cfgIss.SubjectDomain,
-		}
+		issuer := fc.toIssuer(cfgIss)
+		issuer.Issuer = &fulciogrpc.OIDCIssuer_IssuerUrl{IssuerUrl: cfgIss.IssuerURL}
 		issuers = append(issuers, issuer)
 	}
 
 	for metaIss, cfgIss := range fc.MetaIssuers {
-		issuer := &fulciogrpc.OIDCIssuer{
-			Issuer:            &fulciogrpc.OIDCIssuer_WildcardIssuerUrl{WildcardIssuerUrl: metaIss},
-			Audience:          cfgIss.ClientID,
-			SpiffeTrustDomain: cfgIss.SPIFFETrustDomain,
-			ChallengeClaim:    issuerToChallengeClaim(cfgIss.Type, cfgIss.ChallengeClaim),
-			IssuerType:        cfgIss.Type.String(),
-			SubjectDomain:     cfgIss.SubjectDomain,
-		}
+		issuer := fc.toIssuer(cfgIss)
+		issuer.Issuer = &fulciogrpc.OIDCIssuer_WildcardIssuerUrl{WildcardIssuerUrl: metaIss}
 		issuers = append(issuers, issuer)
 	}
 
 	return issuers
 }
 
-func (fc *FulcioConfig) prepare() error {
-	if _, ok := fc.GetIssuer("https://kubernetes.default.svc"); ok {
-		// Add the Kubernetes cluster's CA to the system CA pool, and to
-		// the default transport.
-		rootCAs, _ := x509.SystemCertPool()
-		if rootCAs == nil {
-			rootCAs = x509.NewCertPool()
-		}
-		const k8sCA = "/var/run/fulcio/ca.crt"
-		certs, err := os.ReadFile(k8sCA)
-		if err != nil {
-			return fmt.Errorf("read file: %w", err)
+// toIssuer describes cfgIss, apart from its URL.
+func (fc *FulcioConfig) toIssuer(cfgIss OIDCIssuer) *fulciogrpc.OIDCIssuer {
+	maxLifetime := time.Duration(cfgIss.MaxCertificateLifetime)
+	if maxLifetime <= 0 {
+		maxLifetime = cfgIss.CertificateLifetime(0)
+	}
+	issuer := &fulciogrpc.OIDCIssuer{
+		Audience:          cfgIss.ClientID,
+		SpiffeTrustDomain: cfgIss.SPIFFETrustDomain,
+		ChallengeClaim:    issuerToChallengeClaim(cfgIss.Type, cfgIss.ChallengeClaim),
+		IssuerType:        cfgIss.Type.String(),
+		SubjectDomain:     cfgIss.SubjectDomain,
+		Description:       cfgIss.Description,
+		Contact:           cfgIss.Contact,
+		RequireChallenge:  cfgIss.RequireChallenge,
+		CertificateProfile: &fulciogrpc.CertificateProfile{
+			DefaultLifetime: durationpb.New(cfgIss.CertificateLifetime(0)),
+			MaxLifetime:     durationpb.New(maxLifetime),
+			Backdate:        durationpb.New(time.Duration(cfgIss.CertificateBackdate)),
+		},
+	}
+	if kp := cfgIss.KeyPolicy; kp != nil {
+		issuer.KeyPolicy = &fulciogrpc.KeyPolicy{
+			Algorithms:  kp.Algorithms,
+			EcdsaCurves: kp.ECDSACurves,
+			MinRsaBits:  int32(kp.MinRSABits),
 		}
-		if ok := rootCAs.AppendCertsFromPEM(certs); !ok {
-			return fmt.Errorf("unable to append certs")
+	}
+	if cfgIss.Type == IssuerTypeCIProvider {
+		issuer.CiProvider = cfgIss.CIProvider
+		if metadata, ok := fc.CIIssuerMetadata[cfgIss.CIProvider]; ok {
+			for _, field := range metadata.ExtensionTemplates.Fields() {
+				issuer.ExtensionTemplates = append(issuer.ExtensionTemplates, &fulciogrpc.ExtensionTemplate{
+					Oid:      field.OID.String(),
+					Name:     field.Name,
+					Template: field.Value,
+				})
+			}
+			issuer.DefaultTemplateValues = metadata.DefaultTemplateValues
+			issuer.SubjectAlternativeNameTemplate = metadata.SubjectAlternativeNameTemplate
 		}
+	}
+	return issuer
+}
 
-		t := originalTransport.(*http.Transport).Clone()
-		t.TLSClientConfig.RootCAs = rootCAs
-		http.DefaultTransport = t
-	} else {
-		// If we parse a config that doesn't include a cluster issuer
-		// signed with the cluster'sCA, then restore the original transport
-		// (in case we overwrote it)
-		http.DefaultTransport = originalTransport
+func (fc *FulcioConfig) prepare() error {
+	// Discover the issuers concurrently, so that a slow issuer delays
+	// loading the configuration by at most one timeout
+	type discovered struct {
+		iss      OIDCIssuer
+		provider *oidc.Provider
+		err      error
+	}
+	results := make(chan discovered, len(fc.OIDCIssuers))
+	for _, iss := range fc.OIDCIssuers {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), defaultOIDCDiscoveryTimeout)
+			defer cancel()
+			provider, err := oidc.NewProvider(ctx, iss.IssuerURL)
+			results <- discovered{iss, provider, err}
+		}()
 	}
 
 	fc.verifiers = make(map[string][]*verifierWithConfig, len(fc.OIDCIssuers))
-	for _, iss := range fc.OIDCIssuers {
-		ctx, cancel := context.WithTimeout(context.Background(), defaultOIDCDiscoveryTimeout)
-		defer cancel()
-		provider, err := oidc.NewProvider(ctx, iss.IssuerURL)
-		if err != nil {
-			log.Logger.Errorf("error creating provider for issuer URL %q: %v", iss.IssuerURL, err)
-		} else {
-			cfg := &oidc.Config{ClientID: iss.ClientID}
-			fc.verifiers[iss.IssuerURL] = []*verifierWithConfig{{provider.Verifier(cfg), cfg}}
+	var errs []error
+	for range fc.OIDCIssuers {
+		d := <-results
+		if d.err != nil {
+			log.Logger.Errorf("error creating provider for issuer URL %q: %v", d.iss.IssuerURL, d.err)
+			errs = append(errs, fmt.Errorf("discovering issuer %s: %w", d.iss.IssuerURL, d.err))
+			continue
 		}
+		cfg := &oidc.Config{ClientID: d.iss.ClientID}
+		fc.verifiers[d.iss.IssuerURL] = []*verifierWithConfig{{d.provider.Verifier(cfg), cfg}}
 	}
+	fc.discoveryErr = errors.Join(errs...)
 
 	cache, err := lru.New2Q(100 /* size */)
 	if err != nil {
@@ -417,6 +601,22 @@ func validateConfig(conf *FulcioConfig) error {
 		if issuerToChallengeClaim(issuer.Type, issuer.ChallengeClaim) == "" {
 			return errors.New("issuer missing challenge claim")
 		}
+
+		if err := validateCertificateLifetime(issuer); err != nil {
+			return err
+		}
+
+		if err := validateRateLimit(issuer.IssuerURL, issuer.RateLimit); err != nil {
+			return err
+		}
+
+		if err := issuer.KeyPolicy.validate(); err != nil {
+			return fmt.Errorf("key policy for issuer %s: %w", issuer.IssuerURL, err)
+		}
+
+		if err := validatePolicy(issuer.IssuerURL, issuer.Policy); err != nil {
+			return err
+		}
 	}
 
 	for _, metaIssuer := range conf.MetaIssuers {
@@ -429,6 +629,28 @@ func validateConfig(conf *FulcioConfig) error {
 		if issuerToChallengeClaim(metaIssuer.Type, metaIssuer.ChallengeClaim) == "" {
 			return errors.New("issuer missing challenge claim")
 		}
+
+		if err := validateCertificateLifetime(metaIssuer); err != nil {
+			return err
+		}
+
+		if err := validateRateLimit(metaIssuer.IssuerURL, metaIssuer.RateLimit); err != nil {
+			return err
+		}
+
+		if err := metaIssuer.KeyPolicy.validate(); err != nil {
+			return fmt.Errorf("key policy for issuer %s: %w", metaIssuer.IssuerURL, err)
+		}
+
+		if err := validatePolicy(metaIssuer.IssuerURL, metaIssuer.Policy); err != nil {
+			return err
+		}
+	}
+
+	for issuerURL, issuer := range conf.ClientCertificateIssuers {
+		if err := validateClientCertificateIssuer(conf, issuerURL, issuer); err != nil {
+			return err
+		}
 	}
 
 	return validateCIIssuerMetadata(conf)
@@ -455,8 +677,6 @@ var DefaultConfig = &FulcioConfig{
 	},
 }
 
-var originalTransport = http.DefaultTransport
-
 type configKey struct{}
 
 func With(ctx context.Context, cfg *FulcioConfig) context.Context {
@@ -500,25 +720,73 @@ func validateCIIssuerMetadata(fulcioConfig *FulcioConfig) error {
 	return nil
 }
 
-// Load a config from disk, or use defaults
+// Load a config from disk, or use defaults. Load must only be called at
+// startup, since it configures the default HTTP transport for the config.
 func Load(configPath string) (*FulcioConfig, error) {
+	var config *FulcioConfig
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		log.Logger.Infof("No config at %s, using defaults: %v", configPath, DefaultConfig)
-		config := DefaultConfig
-		if err := config.prepare(); err != nil {
+		config = DefaultConfig
+	} else {
+		b, err := os.ReadFile(configPath)
+		if err != nil {
+			return nil, fmt.Errorf("read file: %w", err)
+		}
+		if config, err = decode(b); err != nil {
 			return nil, err
 		}
-		return config, nil
 	}
-	b, err := os.ReadFile(configPath)
+	// The cluster issuer is discovered with the cluster's CA
+	if err := configureTransport(config); err != nil {
+		return nil, err
+	}
+	if err := config.prepare(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// configureTransport adds the Kubernetes cluster's CA to the system CA pool of
+// the default transport if the config includes the cluster issuer. The
+// transport is shared by every request, so it is only replaced at startup
+// rather than on every reload of the config.
+func configureTransport(fc *FulcioConfig) error {
+	if _, ok := fc.GetIssuer("https://kubernetes.default.svc"); !ok {
+		return nil
+	}
+	rootCAs, _ := x509.SystemCertPool()
+	if rootCAs == nil {
+		rootCAs = x509.NewCertPool()
+	}
+	const k8sCA = "/var/run/fulcio/ca.crt"
+	certs, err := os.ReadFile(k8sCA)
 	if err != nil {
-		return nil, fmt.Errorf("read file: %w", err)
+		return fmt.Errorf("read file: %w", err)
 	}
-	return Read(b)
+	if ok := rootCAs.AppendCertsFromPEM(certs); !ok {
+		return fmt.Errorf("unable to append certs")
+	}
+
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.TLSClientConfig.RootCAs = rootCAs
+	http.DefaultTransport = t
+	return nil
 }
 
 // Read parses the bytes of a config
 func Read(b []byte) (*FulcioConfig, error) {
+	config, err := decode(b)
+	if err != nil {
+		return nil, err
+	}
+	if err := config.prepare(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// decode parses and validates the bytes of a config
+func decode(b []byte) (*FulcioConfig, error) {
 	config, err := parseConfig(b)
 	if err != nil {
 		return nil, fmt.Errorf("parse: %w", err)
@@ -528,11 +796,51 @@ func Read(b []byte) (*FulcioConfig, error) {
 	if err != nil {
 		return nil, fmt.Errorf("validate: %w", err)
 	}
+	return config, nil
+}
 
-	if err := config.prepare(); err != nil {
-		return nil, err
+// validateCertificateLifetime checks that the configured certificate lifetimes
+// of an issuer are consistent
+func validateCertificateLifetime(issuer OIDCIssuer) error {
+	if issuer.DefaultCertificateLifetime < 0 || issuer.MaxCertificateLifetime < 0 || issuer.CertificateBackdate < 0 {
+		return fmt.Errorf("certificate lifetimes and backdate for issuer %s must not be negative", issuer.IssuerURL)
 	}
-	return config, nil
+	if issuer.MaxCertificateLifetime > 0 && issuer.DefaultCertificateLifetime > issuer.MaxCertificateLifetime {
+		return fmt.Errorf("default certificate lifetime for issuer %s exceeds the maximum lifetime", issuer.IssuerURL)
+	}
+	return nil
+}
+
+// validateClientCertificateIssuer checks that a client certificate issuer has
+// CAs and a valid trust domain, and doesn't shadow an OIDC issuer
+func validateClientCertificateIssuer(conf *FulcioConfig, issuerURL string, issuer ClientCertificateIssuer) error {
+	if issuer.IssuerURL != issuerURL {
+		return fmt.Errorf("client certificate issuer %s must have a matching IssuerURL", issuerURL)
+	}
+	if _, ok := conf.OIDCIssuers[issuerURL]; ok {
+		return fmt.Errorf("client certificate issuer %s is also configured as an OIDC issuer", issuerURL)
+	}
+	if issuer.ClientCAPath == "" {
+		return fmt.Errorf("client certificate issuer %s must have ClientCAPath set", issuerURL)
+	}
+	if _, err := spiffeid.TrustDomainFromString(issuer.SPIFFETrustDomain); err != nil {
+		return fmt.Errorf("client certificate issuer %s has an invalid SPIFFE trust domain: %w", issuerURL, err)
+	}
+	if err := validateRateLimit(issuerURL, issuer.RateLimit); err != nil {
+		return err
+	}
+	return validatePolicy(issuerURL, issuer.Policy)
+}
+
+// validateRateLimit checks that the rate limits of an issuer are not negative
+func validateRateLimit(issuerURL string, rl *RateLimit) error {
+	if rl == nil {
+		return nil
+	}
+	if rl.IdentityPerMinute < 0 || rl.IdentityBurst < 0 || rl.IssuerPerMinute < 0 || rl.IssuerBurst < 0 {
+		return fmt.Errorf("rate limits for issuer %s must not be negative", issuerURL)
+	}
+	return nil
 }
 
 // isURISubjectAllowed compares the subject and issuer URIs,
@@ -20,11 +20,15 @@ import (
 	"fmt"
 	"net/url"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/coreos/go-oidc/v3/oidc"
 	lru "github.com/hashicorp/golang-lru"
+	"github.com/sigstore/fulcio/pkg/certificate"
 	"github.com/sigstore/fulcio/pkg/generated/protobuf"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 var validYamlCfg = `
@@ -342,6 +346,125 @@ func TestValidateConfig(t *testing.T) {
 			},
 			WantError: true,
 		},
+		"certificate lifetime within bounds is valid": {
+			Config: &FulcioConfig{
+				OIDCIssuers: map[string]OIDCIssuer{
+					"https://issuer.example.com": {
+						IssuerURL:                  "https://issuer.example.com",
+						ClientID:                   "sigstore",
+						Type:                       IssuerTypeEmail,
+						DefaultCertificateLifetime: Duration(time.Hour),
+						MaxCertificateLifetime:     Duration(2 * time.Hour),
+						CertificateBackdate:        Duration(time.Minute),
+					},
+				},
+			},
+			WantError: false,
+		},
+		"default certificate lifetime cannot exceed max lifetime": {
+			Config: &FulcioConfig{
+				OIDCIssuers: map[string]OIDCIssuer{
+					"https://issuer.example.com": {
+						IssuerURL:                  "https://issuer.example.com",
+						ClientID:                   "sigstore",
+						Type:                       IssuerTypeEmail,
+						DefaultCertificateLifetime: Duration(2 * time.Hour),
+						MaxCertificateLifetime:     Duration(time.Hour),
+					},
+				},
+			},
+			WantError: true,
+		},
+		"negative certificate backdate is invalid": {
+			Config: &FulcioConfig{
+				MetaIssuers: map[string]OIDCIssuer{
+					"https://*.example.com": {
+						ClientID:            "sigstore",
+						Type:                IssuerTypeKubernetes,
+						CertificateBackdate: Duration(-time.Minute),
+					},
+				},
+			},
+			WantError: true,
+		},
+		"negative rate limit is invalid": {
+			Config: &FulcioConfig{
+				OIDCIssuers: map[string]OIDCIssuer{
+					"https://issuer.example.com": {
+						IssuerURL: "https://issuer.example.com",
+						ClientID:  "sigstore",
+						Type:      IssuerTypeEmail,
+						RateLimit: &RateLimit{IdentityPerMinute: -1},
+					},
+				},
+			},
+			WantError: true,
+		},
+		"good client certificate issuer": {
+			Config: &FulcioConfig{
+				ClientCertificateIssuers: map[string]ClientCertificateIssuer{
+					"https://spire.example.com": {
+						IssuerURL:         "https://spire.example.com",
+						ClientCAPath:      "/etc/fulcio/spire-bundle.pem",
+						SPIFFETrustDomain: "example.com",
+					},
+				},
+			},
+			WantError: false,
+		},
+		"client certificate issuer requires client CAs": {
+			Config: &FulcioConfig{
+				ClientCertificateIssuers: map[string]ClientCertificateIssuer{
+					"https://spire.example.com": {
+						IssuerURL:         "https://spire.example.com",
+						SPIFFETrustDomain: "example.com",
+					},
+				},
+			},
+			WantError: true,
+		},
+		"client certificate issuer requires a trust domain": {
+			Config: &FulcioConfig{
+				ClientCertificateIssuers: map[string]ClientCertificateIssuer{
+					"https://spire.example.com": {
+						IssuerURL:    "https://spire.example.com",
+						ClientCAPath: "/etc/fulcio/spire-bundle.pem",
+					},
+				},
+			},
+			WantError: true,
+		},
+		"client certificate issuer URL must match its key": {
+			Config: &FulcioConfig{
+				ClientCertificateIssuers: map[string]ClientCertificateIssuer{
+					"https://spire.example.com": {
+						IssuerURL:         "https://other.example.com",
+						ClientCAPath:      "/etc/fulcio/spire-bundle.pem",
+						SPIFFETrustDomain: "example.com",
+					},
+				},
+			},
+			WantError: true,
+		},
+		"client certificate issuer cannot shadow an OIDC issuer": {
+			Config: &FulcioConfig{
+				OIDCIssuers: map[string]OIDCIssuer{
+					"https://spire.example.com": {
+						IssuerURL: "https://spire.example.com",
+						ClientID:  "sigstore",
+						Type:      IssuerTypeEmail,
+					},
+				},
+				ClientCertificateIssuers: map[string]ClientCertificateIssuer{
+					"https://spire.example.com": {
+						IssuerURL:         "https://spire.example.com",
+						ClientCAPath:      "/etc/fulcio/spire-bundle.pem",
+						SPIFFETrustDomain: "example.com",
+					},
+				},
+			},
+			WantError: true,
+		},
 		"nil config isn't valid": {
 			Config:    nil,
 			WantError: true,
@@ -361,6 +484,98 @@ func TestValidateConfig(t *testing.T) {
 	}
 }
 
+func TestCertificateLifetime(t *testing.T) {
+	tests := map[string]struct {
+		Issuer    OIDCIssuer
+		Requested time.Duration
+		Want      time.Duration
+	}{
+		"unconfigured issuer uses default": {
+			Want: DefaultCertificateLifetime,
+		},
+		"unconfigured issuer clamps to default": {
+			Requested: time.Hour,
+			Want:      DefaultCertificateLifetime,
+		},
+		"shorter request is honored": {
+			Requested: time.Minute,
+			Want:      time.Minute,
+		},
+		"configured default": {
+			Issuer: OIDCIssuer{DefaultCertificateLifetime: Duration(time.Hour)},
+			Want:   time.Hour,
+		},
+		"configured max": {
+			Issuer:    OIDCIssuer{MaxCertificateLifetime: Duration(time.Hour)},
+			Requested: 30 * time.Minute,
+			Want:      30 * time.Minute,
+		},
+		"clamped to configured max": {
+			Issuer:    OIDCIssuer{MaxCertificateLifetime: Duration(time.Hour)},
+			Requested: 2 * time.Hour,
+			Want:      time.Hour,
+		},
+		"default clamped to configured max": {
+			Issuer: OIDCIssuer{MaxCertificateLifetime: Duration(5 * time.Minute)},
+			Want:   5 * time.Minute,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := test.Issuer.CertificateLifetime(test.Requested); got != test.Want {
+				t.Errorf("CertificateLifetime(%v) = %v, wanted %v", test.Requested, got, test.Want)
+			}
+		})
+	}
+}
+
+func TestCertificateLifetimeConfig(t *testing.T) {
+	yamlCfg := `
+oidc-issuers:
+  https://accounts.google.com:
+    issuer-url: https://accounts.google.com
+    client-id: foo
+    type: email
+    default-certificate-lifetime: 1h
+    max-certificate-lifetime: 2h
+    certificate-backdate: 1m
+`
+	jsonCfg := `
+{
+	"OIDCIssuers": {
+		"https://accounts.google.com": {
+			"IssuerURL": "https://accounts.google.com",
+			"ClientID": "foo",
+			"Type": "email",
+			"DefaultCertificateLifetime": "1h",
+			"MaxCertificateLifetime": "2h",
+			"CertificateBackdate": "1m"
+		}
+	}
+}
+`
+	for _, cfgStr := range []string{yamlCfg, jsonCfg} {
+		cfg, err := Read([]byte(cfgStr))
+		if err != nil {
+			t.Fatal(err)
+		}
+		iss, ok := cfg.GetIssuer("https://accounts.google.com")
+		if !ok {
+			t.Fatal("expected issuer to be found")
+		}
+		if iss.DefaultCertificateLifetime != Duration(time.Hour) ||
+			iss.MaxCertificateLifetime != Duration(2*time.Hour) ||
+			iss.CertificateBackdate != Duration(time.Minute) {
+			t.Errorf("unexpected lifetimes in %+v", iss)
+		}
+	}
+
+	if _, err := Read([]byte(strings.Replace(yamlCfg, "2h", "forever", 1))); err == nil {
+		t.Error("expected error parsing invalid duration")
+	}
+}
+
 func Test_isURISubjectAllowed(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -540,6 +755,11 @@ func Test_issuerToChallengeClaim(t *testing.T) {
 }
 
 func TestToIssuers(t *testing.T) {
+	defaultProfile := &protobuf.CertificateProfile{
+		DefaultLifetime: durationpb.New(10 * time.Minute),
+		MaxLifetime:     durationpb.New(10 * time.Minute),
+		Backdate:        durationpb.New(0),
+	}
 	tests := []struct {
 		config *FulcioConfig
 		want   []*protobuf.OIDCIssuer
@@ -567,7 +787,8 @@ func TestToIssuers(t *testing.T) {
 					Issuer: &protobuf.OIDCIssuer_IssuerUrl{
 						IssuerUrl: "example.com",
 					},
-					IssuerType: IssuerTypeEmail,
+					IssuerType:         IssuerTypeEmail,
+					CertificateProfile: defaultProfile,
 				},
 				{
 					Audience:       "sigstore",
@@ -575,7 +796,40 @@ func TestToIssuers(t *testing.T) {
 					Issuer: &protobuf.OIDCIssuer_WildcardIssuerUrl{
 						WildcardIssuerUrl: "wildcard.*.example.com",
 					},
-					IssuerType: IssuerTypeKubernetes,
+					IssuerType:         IssuerTypeKubernetes,
+					CertificateProfile: defaultProfile,
+				},
+			},
+		},
+		{
+			config: &FulcioConfig{
+				OIDCIssuers: map[string]OIDCIssuer{
+					"regulated.example.com": {
+						IssuerURL:        "regulated.example.com",
+						ClientID:         "sigstore",
+						Type:             IssuerTypeEmail,
+						RequireChallenge: true,
+						KeyPolicy: &KeyPolicy{
+							Algorithms:  []string{KeyAlgorithmECDSA},
+							ECDSACurves: []string{CurveP384},
+						},
+					},
+				},
+			},
+			want: []*protobuf.OIDCIssuer{
+				{
+					Audience:       "sigstore",
+					ChallengeClaim: "email",
+					Issuer: &protobuf.OIDCIssuer_IssuerUrl{
+						IssuerUrl: "regulated.example.com",
+					},
+					IssuerType:         IssuerTypeEmail,
+					CertificateProfile: defaultProfile,
+					RequireChallenge:   true,
+					KeyPolicy: &protobuf.KeyPolicy{
+						Algorithms:  []string{"ecdsa"},
+						EcdsaCurves: []string{"P-384"},
+					},
 				},
 			},
 		},
@@ -597,8 +851,9 @@ func TestToIssuers(t *testing.T) {
 					Issuer: &protobuf.OIDCIssuer_IssuerUrl{
 						IssuerUrl: "username.example.com",
 					},
-					IssuerType:    IssuerTypeUsername,
-					SubjectDomain: "username.example.com",
+					IssuerType:         IssuerTypeUsername,
+					CertificateProfile: defaultProfile,
+					SubjectDomain:      "username.example.com",
 				},
 			},
 		},
@@ -620,8 +875,60 @@ func TestToIssuers(t *testing.T) {
 					Issuer: &protobuf.OIDCIssuer_IssuerUrl{
 						IssuerUrl: "uriissuer.example.com",
 					},
-					IssuerType:    IssuerTypeURI,
-					SubjectDomain: "uriissuer.example.com",
+					IssuerType:         IssuerTypeURI,
+					CertificateProfile: defaultProfile,
+					SubjectDomain:      "uriissuer.example.com",
+				},
+			},
+		},
+		{
+			config: &FulcioConfig{
+				OIDCIssuers: map[string]OIDCIssuer{
+					"ci.example.com": {
+						IssuerURL:                  "ci.example.com",
+						ClientID:                   "sigstore",
+						Type:                       IssuerTypeCIProvider,
+						CIProvider:                 "example-ci",
+						Description:                "Example CI",
+						Contact:                    "ci@example.com",
+						DefaultCertificateLifetime: Duration(time.Hour),
+						MaxCertificateLifetime:     Duration(4 * time.Hour),
+						CertificateBackdate:        Duration(time.Minute),
+					},
+				},
+				CIIssuerMetadata: map[string]IssuerMetadata{
+					"example-ci": {
+						DefaultTemplateValues: map[string]string{"url": "https://ci.example.com"},
+						ExtensionTemplates: certificate.Extensions{
+							BuildSignerURI:    "{{ .url }}/{{ .workflow }}",
+							RunnerEnvironment: "runner_environment",
+						},
+						SubjectAlternativeNameTemplate: "{{ .url }}/{{ .workflow }}",
+					},
+				},
+			},
+			want: []*protobuf.OIDCIssuer{
+				{
+					Audience:       "sigstore",
+					ChallengeClaim: "sub",
+					Issuer: &protobuf.OIDCIssuer_IssuerUrl{
+						IssuerUrl: "ci.example.com",
+					},
+					IssuerType:  IssuerTypeCIProvider,
+					Description: "Example CI",
+					Contact:     "ci@example.com",
+					CiProvider:  "example-ci",
+					ExtensionTemplates: []*protobuf.ExtensionTemplate{
+						{Oid: "1.3.6.1.4.1.57264.1.9", Name: "Build Signer URI", Template: "{{ .url }}/{{ .workflow }}"},
+						{Oid: "1.3.6.1.4.1.57264.1.11", Name: "Runner Environment", Template: "runner_environment"},
+					},
+					DefaultTemplateValues:          map[string]string{"url": "https://ci.example.com"},
+					SubjectAlternativeNameTemplate: "{{ .url }}/{{ .workflow }}",
+					CertificateProfile: &protobuf.CertificateProfile{
+						DefaultLifetime: durationpb.New(time.Hour),
+						MaxLifetime:     durationpb.New(4 * time.Hour),
+						Backdate:        durationpb.New(time.Minute),
+					},
 				},
 			},
 		},
@@ -0,0 +1,106 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Key algorithms and curves that a KeyPolicy can allow
+const (
+	KeyAlgorithmECDSA   = "ecdsa"
+	KeyAlgorithmRSA     = "rsa"
+	KeyAlgorithmED25519 = "ed25519"
+
+	CurveP256 = "P-256"
+	CurveP384 = "P-384"
+	CurveP521 = "P-521"
+)
+
+// KeyPolicy restricts the public keys that certificates are issued for, in
+// addition to the checks for weak keys applied to every request.
+type KeyPolicy struct {
+	// The allowed key algorithms, "ecdsa", "rsa" and "ed25519". Any algorithm
+	// is allowed if empty.
+	Algorithms []string `json:"Algorithms,omitempty" yaml:"algorithms,omitempty"`
+	// The allowed curves of ECDSA keys, "P-256", "P-384" and "P-521". Any
+	// curve is allowed if empty.
+	ECDSACurves []string `json:"ECDSACurves,omitempty" yaml:"ecdsa-curves,omitempty"`
+	// The minimum size of RSA keys in bits. Keys smaller than 2048 bits are
+	// rejected regardless.
+	MinRSABits int `json:"MinRSABits,omitempty" yaml:"min-rsa-bits,omitempty"`
+}
+
+func (p *KeyPolicy) validate() error {
+	if p == nil {
+		return nil
+	}
+	for _, alg := range p.Algorithms {
+		if !slices.Contains([]string{KeyAlgorithmECDSA, KeyAlgorithmRSA, KeyAlgorithmED25519}, alg) {
+			return fmt.Errorf("unknown key algorithm %q", alg)
+		}
+	}
+	for _, curve := range p.ECDSACurves {
+		if !slices.Contains([]string{CurveP256, CurveP384, CurveP521}, curve) {
+			return fmt.Errorf("unknown ECDSA curve %q", curve)
+		}
+	}
+	if p.MinRSABits < 0 {
+		return errors.New("minimum RSA key size must not be negative")
+	}
+	return nil
+}
+
+// Check returns an error if pub is not allowed by the policy. A nil policy
+// allows every key.
+func (p *KeyPolicy) Check(pub crypto.PublicKey) error {
+	if p == nil {
+		return nil
+	}
+	var alg string
+	switch pub.(type) {
+	case *ecdsa.PublicKey:
+		alg = KeyAlgorithmECDSA
+	case *rsa.PublicKey:
+		alg = KeyAlgorithmRSA
+	case ed25519.PublicKey:
+		alg = KeyAlgorithmED25519
+	default:
+		return fmt.Errorf("unsupported public key type %T", pub)
+	}
+	if len(p.Algorithms) > 0 && !slices.Contains(p.Algorithms, alg) {
+		return fmt.Errorf("%s keys are not allowed, must be one of %v", alg, p.Algorithms)
+	}
+
+	switch pub := pub.(type) {
+	case *ecdsa.PublicKey:
+		curve := pub.Curve.Params().Name
+		if len(p.ECDSACurves) > 0 && !slices.Contains(p.ECDSACurves, curve) {
+			return fmt.Errorf("ECDSA curve %s is not allowed, must be one of %v", curve, p.ECDSACurves)
+		}
+	case *rsa.PublicKey:
+		if bits := pub.N.BitLen(); bits < p.MinRSABits {
+			return fmt.Errorf("RSA key size %d is below the minimum of %d bits", bits, p.MinRSABits)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+)
+
+func TestKeyPolicyCheck(t *testing.T) {
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsa2048, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p384Only := &KeyPolicy{Algorithms: []string{KeyAlgorithmECDSA}, ECDSACurves: []string{CurveP384}}
+	noEd25519 := &KeyPolicy{Algorithms: []string{KeyAlgorithmECDSA, KeyAlgorithmRSA}, MinRSABits: 3072}
+
+	tests := map[string]struct {
+		policy  *KeyPolicy
+		pub     crypto.PublicKey
+		wantErr bool
+	}{
+		"nil policy allows any key":     {nil, edPub, false},
+		"empty policy allows any key":   {&KeyPolicy{}, rsa2048.Public(), false},
+		"allowed curve":                 {p384Only, p384.Public(), false},
+		"disallowed curve":              {p384Only, p256.Public(), true},
+		"disallowed algorithm":          {p384Only, rsa2048.Public(), true},
+		"denied ed25519":                {noEd25519, edPub, true},
+		"RSA key below minimum size":    {noEd25519, rsa2048.Public(), true},
+		"ECDSA key with any curve":      {noEd25519, p256.Public(), false},
+		"unsupported key type rejected": {&KeyPolicy{}, "not a key", true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.policy.Check(test.pub)
+			if (err != nil) != test.wantErr {
+				t.Errorf("Check() = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestKeyPolicyValidate(t *testing.T) {
+	tests := map[string]struct {
+		policy  *KeyPolicy
+		wantErr bool
+	}{
+		"nil policy":          {nil, false},
+		"valid policy":        {&KeyPolicy{Algorithms: []string{"ecdsa"}, ECDSACurves: []string{"P-384"}, MinRSABits: 4096}, false},
+		"unknown algorithm":   {&KeyPolicy{Algorithms: []string{"dsa"}}, true},
+		"unknown curve":       {&KeyPolicy{ECDSACurves: []string{"secp256k1"}}, true},
+		"negative RSA size":   {&KeyPolicy{MinRSABits: -1}, true},
+		"case sensitive name": {&KeyPolicy{Algorithms: []string{"ECDSA"}}, true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.policy.validate()
+			if (err != nil) != test.wantErr {
+				t.Errorf("validate() = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+)
+
+// ErrPolicyDenied is returned when an issuer's policy does not allow a request.
+var ErrPolicyDenied = errors.New("denied by issuer policy")
+
+// Variables available to issuer policies
+const (
+	// PolicyVariableClaims is a map of the claims of the verified token.
+	PolicyVariableClaims = "claims"
+	// PolicyVariablePrincipal is the name of the authenticated principal,
+	// which is the subject of the certificate.
+	PolicyVariablePrincipal = "principal"
+	// PolicyVariableIssuer is the URL of the issuer of the credentials.
+	PolicyVariableIssuer = "issuer"
+)
+
+var (
+	policyEnvOnce sync.Once
+	policyEnv     *cel.Env
+	policyEnvErr  error
+
+	// policyPrograms caches compiled policies by their expression, since
+	// meta issuers share a policy across many issuer URLs.
+	policyPrograms sync.Map
+)
+
+func policyEnvironment() (*cel.Env, error) {
+	policyEnvOnce.Do(func() {
+		policyEnv, policyEnvErr = cel.NewEnv(
+			cel.Variable(PolicyVariableClaims, cel.MapType(cel.StringType, cel.DynType)),
+			cel.Variable(PolicyVariablePrincipal, cel.StringType),
+			cel.Variable(PolicyVariableIssuer, cel.StringType),
+		)
+	})
+	return policyEnv, policyEnvErr
+}
+
+// compilePolicy compiles a CEL policy expression, which must evaluate to a
+// boolean.
+func compilePolicy(expr string) (cel.Program, error) {
+	if prg, ok := policyPrograms.Load(expr); ok {
+		return prg.(cel.Program), nil
+	}
+	env, err := policyEnvironment()
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(expr)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if ast.OutputType() != cel.BoolType {
+		return nil, fmt.Errorf("policy must evaluate to a bool, not %v", ast.OutputType())
+	}
+	prg, err := env.Program(ast)
+	if err != nil {
+		return nil, err
+	}
+	policyPrograms.Store(expr, prg)
+	return prg, nil
+}
+
+// validatePolicy checks that the policy of an issuer compiles
+func validatePolicy(issuerURL, policy string) error {
+	if policy == "" {
+		return nil
+	}
+	if _, err := compilePolicy(policy); err != nil {
+		return fmt.Errorf("policy for issuer %s: %w", issuerURL, err)
+	}
+	return nil
+}
+
+// CheckPolicy evaluates the policy of the issuer against the claims of the
+// verified token and the name of the principal, and returns ErrPolicyDenied if
+// the policy does not allow them. Every request is allowed if the issuer has
+// no policy. A policy that fails to evaluate, for example because it refers
+// to a claim that is missing from the token, denies the request.
+func (fic OIDCIssuer) CheckPolicy(claims map[string]any, principal string) error {
+	return checkPolicy(fic.Policy, fic.IssuerURL, claims, principal)
+}
+
+// CheckPolicy evaluates the policy of the issuer against the SPIFFE ID of a
+// verified client certificate, as for OIDCIssuer.CheckPolicy. There are no
+// token claims, so claims is an empty map.
+func (cci ClientCertificateIssuer) CheckPolicy(principal string) error {
+	return checkPolicy(cci.Policy, cci.IssuerURL, nil, principal)
+}
+
+func checkPolicy(policy, issuerURL string, claims map[string]any, principal string) error {
+	if policy == "" {
+		return nil
+	}
+	prg, err := compilePolicy(policy)
+	if err != nil {
+		return fmt.Errorf("compiling policy: %w", err)
+	}
+	if claims == nil {
+		claims = map[string]any{}
+	}
+	out, _, err := prg.Eval(map[string]any{
+		PolicyVariableClaims:    claims,
+		PolicyVariablePrincipal: principal,
+		PolicyVariableIssuer:    issuerURL,
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrPolicyDenied, err)
+	}
+	if out != types.True {
+		return ErrPolicyDenied
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckPolicy(t *testing.T) {
+	claims := map[string]any{
+		"repository_owner": "our-org",
+		"ref":              "refs/tags/v1.0.0",
+		"run_attempt":      float64(1),
+	}
+	policy := `claims.repository_owner == "our-org" && claims.ref.startsWith("refs/tags/")`
+
+	tests := map[string]struct {
+		policy    string
+		claims    map[string]any
+		principal string
+		wantErr   bool
+	}{
+		"no policy allows everything": {"", nil, "foo", false},
+		"allowed claims":              {policy, claims, "foo", false},
+		"denied owner": {policy, map[string]any{
+			"repository_owner": "someone-else",
+			"ref":              "refs/tags/v1.0.0",
+		}, "foo", true},
+		"denied ref": {policy, map[string]any{
+			"repository_owner": "our-org",
+			"ref":              "refs/heads/main",
+		}, "foo", true},
+		"missing claim is denied":  {policy, map[string]any{"repository_owner": "our-org"}, "foo", true},
+		"nil claims are denied":    {policy, nil, "foo", true},
+		"principal":                {`principal.endsWith("@example.com")`, nil, "foo@example.com", false},
+		"denied principal":         {`principal.endsWith("@example.com")`, nil, "foo@example.org", true},
+		"issuer":                   {`issuer == "https://issuer.example.com"`, nil, "foo", false},
+		"numeric claim":            {`claims.run_attempt == 1`, claims, "foo", false},
+		"membership of list claim": {`"admins" in claims.groups`, map[string]any{"groups": []any{"users", "admins"}}, "foo", false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			iss := OIDCIssuer{IssuerURL: "https://issuer.example.com", Policy: test.policy}
+			err := iss.CheckPolicy(test.claims, test.principal)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("CheckPolicy() = %v, wantErr %v", err, test.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrPolicyDenied) {
+				t.Errorf("CheckPolicy() = %v, wanted ErrPolicyDenied", err)
+			}
+		})
+	}
+}
+
+func TestValidatePolicy(t *testing.T) {
+	tests := map[string]struct {
+		policy  string
+		wantErr bool
+	}{
+		"no policy":         {"", false},
+		"valid policy":      {`claims.sub == "foo" || principal == "bar"`, false},
+		"syntax error":      {`claims.sub ==`, true},
+		"unknown variable":  {`repository_owner == "our-org"`, true},
+		"not a bool":        {`claims.sub`, true},
+		"string expression": {`principal + "suffix"`, true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := validatePolicy("https://issuer.example.com", test.policy)
+			if (err != nil) != test.wantErr {
+				t.Errorf("validatePolicy() = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
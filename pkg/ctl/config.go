@@ -0,0 +1,130 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package ctl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LogsConfig lists the CT logs that certificates are submitted to.
+type LogsConfig struct {
+	// The number of logs that must return an SCT for a certificate to be
+	// issued. Defaults to every log.
+	Quorum int         `json:"Quorum,omitempty" yaml:"quorum,omitempty"`
+	Logs   []LogConfig `json:"Logs" yaml:"logs"`
+}
+
+// LogConfig configures a CT log, or a temporal shard of one.
+type LogConfig struct {
+	// The URL of the log, including the log prefix. For static-ct-api logs,
+	// this is the submission prefix.
+	URL string `json:"URL,omitempty" yaml:"url,omitempty"`
+	// Optional, the API the log implements, LogTypeRFC6962 (the default) or
+	// LogTypeStaticCTAPI
+	Type string `json:"Type,omitempty" yaml:"type,omitempty"`
+	// For static-ct-api logs, the monitoring prefix of the log, from which
+	// its checkpoint is fetched by health checks
+	MonitoringURL string `json:"MonitoringURL,omitempty" yaml:"monitoring-url,omitempty"`
+	// Optional, path to a PEM-encoded public key of the log, used to verify SCTs
+	PublicKeyPath string `json:"PublicKeyPath,omitempty" yaml:"public-key-path,omitempty"`
+	// Optional, path to a PEM file of the CA certificates trusted for TLS
+	// connections to the log
+	TLSCACertPath string `json:"TLSCACertPath,omitempty" yaml:"tls-ca-cert-path,omitempty"`
+	// For shards, the range of certificate expiry times the shard accepts,
+	// from NotAfterStart inclusive to NotAfterLimit exclusive
+	NotAfterStart time.Time `json:"NotAfterStart,omitempty" yaml:"not-after-start,omitempty"`
+	NotAfterLimit time.Time `json:"NotAfterLimit,omitempty" yaml:"not-after-limit,omitempty"`
+	// Optional, the temporal shards of a log, instead of its URL. Each
+	// certificate is submitted to the shard whose window covers its expiry.
+	Shards []LogConfig `json:"Shards,omitempty" yaml:"shards,omitempty"`
+}
+
+// ReadLogsConfig parses and validates a JSON or YAML CT logs configuration.
+func ReadLogsConfig(b []byte) (*LogsConfig, error) {
+	cfg := &LogsConfig{}
+	if err := json.Unmarshal(b, cfg); err != nil {
+		if err = yaml.Unmarshal(b, cfg); err != nil {
+			return nil, fmt.Errorf("unmarshal: %w", err)
+		}
+	}
+
+	if len(cfg.Logs) == 0 {
+		return nil, errors.New("no CT logs configured")
+	}
+	for i, l := range cfg.Logs {
+		if err := l.validate(); err != nil {
+			return nil, fmt.Errorf("CT log %d: %w", i, err)
+		}
+	}
+	if cfg.Quorum < 0 || cfg.Quorum > len(cfg.Logs) {
+		return nil, fmt.Errorf("quorum %d must be between 1 and the number of CT logs, %d", cfg.Quorum, len(cfg.Logs))
+	}
+	return cfg, nil
+}
+
+func (l LogConfig) validate() error {
+	if len(l.Shards) == 0 {
+		if l.URL == "" {
+			return errors.New("no URL")
+		}
+		if !l.NotAfterStart.IsZero() || !l.NotAfterLimit.IsZero() {
+			return errors.New("only shards have a window")
+		}
+		return l.validateType()
+	}
+	if l.URL != "" {
+		return errors.New("a sharded log has no URL of its own")
+	}
+	if l.Type != "" || l.MonitoringURL != "" {
+		return errors.New("a sharded log has no type of its own, it is set for each shard")
+	}
+	for i, s := range l.Shards {
+		if s.URL == "" {
+			return fmt.Errorf("shard %d has no URL", i)
+		}
+		if len(s.Shards) > 0 {
+			return fmt.Errorf("shard %d has shards", i)
+		}
+		if s.NotAfterStart.IsZero() || s.NotAfterLimit.IsZero() {
+			return fmt.Errorf("shard %d has no window", i)
+		}
+		if err := s.validateType(); err != nil {
+			return fmt.Errorf("shard %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (l LogConfig) validateType() error {
+	switch l.Type {
+	case "", LogTypeRFC6962:
+		if l.MonitoringURL != "" {
+			return errors.New("only static-ct-api logs have a monitoring URL")
+		}
+	case LogTypeStaticCTAPI:
+		if l.MonitoringURL == "" {
+			return errors.New("static-ct-api log has no monitoring URL")
+		}
+	default:
+		return fmt.Errorf("unknown log type %q, must be %q or %q", l.Type, LogTypeRFC6962, LogTypeStaticCTAPI)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctl
+
+import (
+	"testing"
+)
+
+func TestReadLogsConfig(t *testing.T) {
+	tests := map[string]struct {
+		config     string
+		wantErr    bool
+		wantLogs   int
+		wantQuorum int
+	}{
+		"JSON": {`{
+			"Quorum": 1,
+			"Logs": [
+				{"URL": "https://ct1.example.com/log", "PublicKeyPath": "/etc/ct/ct1.pem"},
+				{"URL": "https://ct2.example.com/log"}
+			]
+		}`, false, 2, 1},
+		"YAML": {`
+logs:
+  - url: https://ct1.example.com/log
+    public-key-path: /etc/ct/ct1.pem
+  - url: https://ct2.example.com/log
+    tls-ca-cert-path: /etc/ct/ca.pem
+`, false, 2, 0},
+		"sharded log": {`
+logs:
+  - shards:
+      - url: https://ct1.example.com/2025
+        not-after-start: 2025-01-01T00:00:00Z
+        not-after-limit: 2026-01-01T00:00:00Z
+      - url: https://ct1.example.com/2026
+        not-after-start: 2026-01-01T00:00:00Z
+        not-after-limit: 2027-01-01T00:00:00Z
+  - url: https://ct2.example.com/log
+`, false, 2, 0},
+		"static-ct-api log": {`
+logs:
+  - url: https://ct1.example.com/2025h1
+    type: static-ct-api
+    monitoring-url: https://ct1.example.com/2025h1/monitor
+  - url: https://ct2.example.com/log
+    type: rfc6962
+`, false, 2, 0},
+		"static-ct-api shard": {`{"Logs": [{"Shards": [
+			{"URL": "https://ct1.example.com/2025", "Type": "static-ct-api", "MonitoringURL": "https://mon.example.com/2025",
+			 "NotAfterStart": "2025-01-01T00:00:00Z", "NotAfterLimit": "2026-01-01T00:00:00Z"}
+		]}]}`, false, 1, 0},
+		"static-ct-api log without monitoring URL": {`{"Logs": [{"URL": "https://ct1.example.com/log", "Type": "static-ct-api"}]}`, true, 0, 0},
+		"monitoring URL of RFC 6962 log":           {`{"Logs": [{"URL": "https://ct1.example.com/log", "MonitoringURL": "https://ct1.example.com/mon"}]}`, true, 0, 0},
+		"unknown log type":                         {`{"Logs": [{"URL": "https://ct1.example.com/log", "Type": "tiles"}]}`, true, 0, 0},
+		"sharded log with type": {`{"Logs": [{"Type": "static-ct-api", "Shards": [
+			{"URL": "https://ct1.example.com/2025", "NotAfterStart": "2025-01-01T00:00:00Z", "NotAfterLimit": "2026-01-01T00:00:00Z"}
+		]}]}`, true, 0, 0},
+		"shard without window": {`{"Logs": [{"Shards": [{"URL": "https://ct1.example.com/2025"}]}]}`, true, 0, 0},
+		"shard without URL": {`{"Logs": [{"Shards": [
+			{"NotAfterStart": "2025-01-01T00:00:00Z", "NotAfterLimit": "2026-01-01T00:00:00Z"}
+		]}]}`, true, 0, 0},
+		"sharded log with URL": {`{"Logs": [{"URL": "https://ct1.example.com/log", "Shards": [
+			{"URL": "https://ct1.example.com/2025", "NotAfterStart": "2025-01-01T00:00:00Z", "NotAfterLimit": "2026-01-01T00:00:00Z"}
+		]}]}`, true, 0, 0},
+		"window without shards": {`{"Logs": [{"URL": "https://ct1.example.com/log", "NotAfterStart": "2025-01-01T00:00:00Z"}]}`, true, 0, 0},
+		"no logs":               {`{"Logs": []}`, true, 0, 0},
+		"missing URL":           {`{"Logs": [{"PublicKeyPath": "/etc/ct/ct1.pem"}]}`, true, 0, 0},
+		"quorum too large":      {`{"Quorum": 2, "Logs": [{"URL": "https://ct1.example.com/log"}]}`, true, 0, 0},
+		"negative quorum":       {`{"Quorum": -1, "Logs": [{"URL": "https://ct1.example.com/log"}]}`, true, 0, 0},
+		"invalid":               {`logs: [`, true, 0, 0},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := ReadLogsConfig([]byte(test.config))
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ReadLogsConfig() = %v, wantErr %v", err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(cfg.Logs) != test.wantLogs || cfg.Quorum != test.wantQuorum {
+				t.Errorf("got %d logs with quorum %d, wanted %d with quorum %d", len(cfg.Logs), cfg.Quorum, test.wantLogs, test.wantQuorum)
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package fakelog implements an in-memory RFC 6962 certificate transparency
+// log, so that certificates can be logged without running a CT log.
+// For testing only.
+package fakelog
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	ct "github.com/google/certificate-transparency-go"
+	"github.com/google/certificate-transparency-go/client"
+	"github.com/google/certificate-transparency-go/jsonclient"
+	"github.com/google/certificate-transparency-go/tls"
+	ctx509 "github.com/google/certificate-transparency-go/x509"
+	"github.com/transparency-dev/merkle/compact"
+	"github.com/transparency-dev/merkle/rfc6962"
+)
+
+// URL is the base URL of the clients returned by Log.Client.
+const URL = "http://fakelog.invalid"
+
+// Log is an in-memory CT log answering add-chain, add-pre-chain and get-sth
+// requests. Chains are not checked against trusted roots, and entries are
+// incorporated into the tree as soon as they are added.
+type Log struct {
+	key   *ecdsa.PrivateKey
+	logID [sha256.Size]byte
+	mux   *http.ServeMux
+
+	mu   sync.Mutex
+	tree *compact.Range
+}
+
+// New returns an empty Log signing with a generated key.
+func New() (*Log, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generating log key: %w", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return nil, fmt.Errorf("marshaling log public key: %w", err)
+	}
+	rf := &compact.RangeFactory{Hash: rfc6962.DefaultHasher.HashChildren}
+	l := &Log{
+		key:   key,
+		logID: sha256.Sum256(der),
+		mux:   http.NewServeMux(),
+		tree:  rf.NewEmptyRange(0),
+	}
+	l.mux.HandleFunc("POST /ct/v1/add-chain", l.handleAdd(ct.X509LogEntryType))
+	l.mux.HandleFunc("POST /ct/v1/add-pre-chain", l.handleAdd(ct.PrecertLogEntryType))
+	l.mux.HandleFunc("GET /ct/v1/get-sth", l.handleGetSTH)
+	return l, nil
+}
+
+// PublicKey returns the public key that SCTs and tree heads are signed with.
+func (l *Log) PublicKey() crypto.PublicKey {
+	return l.key.Public()
+}
+
+// PublicKeyPEM returns the PEM-encoded public key of the log.
+func (l *Log) PublicKeyPEM() ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(l.key.Public())
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+// Size returns the number of entries in the log.
+func (l *Log) Size() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.tree.End()
+}
+
+// ServeHTTP serves the RFC 6962 API of the log under /ct/v1/.
+func (l *Log) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	l.mux.ServeHTTP(w, r)
+}
+
+// Client returns a client for the log, verifying SCTs with its public key,
+// which sends requests to the log in-process rather than over the network.
+func (l *Log) Client() (*client.LogClient, error) {
+	pubKey, err := l.PublicKeyPEM()
+	if err != nil {
+		return nil, err
+	}
+	httpClient := &http.Client{Transport: handlerTransport{l}}
+	return client.New(URL, httpClient, jsonclient.Options{PublicKey: string(pubKey)})
+}
+
+// handlerTransport sends requests to an http.Handler
+type handlerTransport struct {
+	handler http.Handler
+}
+
+func (t handlerTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	t.handler.ServeHTTP(rec, r)
+	resp := rec.Result()
+	resp.Request = r
+	return resp, nil
+}
+
+func (l *Log) handleAdd(etype ct.LogEntryType) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req ct.AddChainRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+			return
+		}
+		chain := make([]ct.ASN1Cert, len(req.Chain))
+		for i, der := range req.Chain {
+			chain[i] = ct.ASN1Cert{Data: der}
+		}
+		if err := checkChain(chain, etype); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resp, err := l.add(chain, etype)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, resp)
+	}
+}
+
+// checkChain checks that chain[0] is a precertificate for add-pre-chain, and
+// a certificate for add-chain.
+func checkChain(chain []ct.ASN1Cert, etype ct.LogEntryType) error {
+	if len(chain) == 0 {
+		return fmt.Errorf("empty chain")
+	}
+	cert, err := ctx509.ParseCertificate(chain[0].Data)
+	if ctx509.IsFatal(err) {
+		return fmt.Errorf("parsing certificate: %w", err)
+	}
+	switch {
+	case etype == ct.PrecertLogEntryType && !cert.IsPrecertificate():
+		return fmt.Errorf("add-pre-chain requires a precertificate")
+	case etype == ct.PrecertLogEntryType && len(chain) < 2:
+		return fmt.Errorf("add-pre-chain requires the issuer of the precertificate")
+	case etype == ct.X509LogEntryType && cert.IsPrecertificate():
+		return fmt.Errorf("add-chain does not accept precertificates")
+	}
+	return nil
+}
+
+// add appends chain[0] to the log and returns its SCT.
+func (l *Log) add(chain []ct.ASN1Cert, etype ct.LogEntryType) (*ct.AddChainResponse, error) {
+	timestamp := uint64(time.Now().UnixMilli())
+	leaf, err := ct.MerkleTreeLeafFromRawChain(chain, etype, timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("building leaf: %w", err)
+	}
+	sct := ct.SignedCertificateTimestamp{
+		SCTVersion: ct.V1,
+		LogID:      ct.LogID{KeyID: l.logID},
+		Timestamp:  timestamp,
+	}
+	input, err := ct.SerializeSCTSignatureInput(sct, ct.LogEntry{Leaf: *leaf})
+	if err != nil {
+		return nil, fmt.Errorf("serializing SCT: %w", err)
+	}
+	sig, err := l.sign(input)
+	if err != nil {
+		return nil, err
+	}
+	leafData, err := tls.Marshal(*leaf)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling leaf: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.tree.Append(rfc6962.DefaultHasher.HashLeaf(leafData), nil); err != nil {
+		return nil, fmt.Errorf("appending leaf: %w", err)
+	}
+	return &ct.AddChainResponse{
+		SCTVersion: sct.SCTVersion,
+		ID:         l.logID[:],
+		Timestamp:  timestamp,
+		Signature:  sig,
+	}, nil
+}
+
+func (l *Log) handleGetSTH(w http.ResponseWriter, _ *http.Request) {
+	l.mu.Lock()
+	size := l.tree.End()
+	root := rfc6962.DefaultHasher.EmptyRoot()
+	if size > 0 {
+		var err error
+		if root, err = l.tree.GetRootHash(nil); err != nil {
+			l.mu.Unlock()
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	l.mu.Unlock()
+
+	sth := ct.SignedTreeHead{
+		Version:   ct.V1,
+		TreeSize:  size,
+		Timestamp: uint64(time.Now().UnixMilli()),
+	}
+	copy(sth.SHA256RootHash[:], root)
+	input, err := ct.SerializeSTHSignatureInput(sth)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sig, err := l.sign(input)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, &ct.GetSTHResponse{
+		TreeSize:          sth.TreeSize,
+		Timestamp:         sth.Timestamp,
+		SHA256RootHash:    sth.SHA256RootHash[:],
+		TreeHeadSignature: sig,
+	})
+}
+
+// sign returns the TLS-encoded signature over data.
+func (l *Log) sign(data []byte) ([]byte, error) {
+	sig, err := tls.CreateSignature(*l.key, tls.SHA256, data)
+	if err != nil {
+		return nil, fmt.Errorf("signing: %w", err)
+	}
+	b, err := tls.Marshal(sig)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling signature: %w", err)
+	}
+	return b, nil
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
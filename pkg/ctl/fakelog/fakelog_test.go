@@ -0,0 +1,117 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fakelog
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"testing"
+
+	"github.com/sigstore/fulcio/pkg/ca/ephemeralca"
+	"github.com/sigstore/fulcio/pkg/ctl"
+)
+
+type testPrincipal struct{}
+
+func (testPrincipal) Name(context.Context) string {
+	return "test@example.com"
+}
+
+func (testPrincipal) Embed(_ context.Context, cert *x509.Certificate) error {
+	cert.EmailAddresses = []string{"test@example.com"}
+	return nil
+}
+
+func TestLog(t *testing.T) {
+	l, err := New()
+	if err != nil {
+		t.Fatalf("New() = %v", err)
+	}
+	// The client verifies SCTs and tree heads with the log public key
+	c, err := l.Client()
+	if err != nil {
+		t.Fatalf("Client() = %v", err)
+	}
+	ctx := context.Background()
+
+	sth, err := c.GetSTH(ctx)
+	if err != nil {
+		t.Fatalf("GetSTH() = %v", err)
+	}
+	if sth.TreeSize != 0 {
+		t.Errorf("got tree size %d, wanted 0", sth.TreeSize)
+	}
+
+	eca, err := ephemeralca.NewEphemeralCA()
+	if err != nil {
+		t.Fatalf("NewEphemeralCA() = %v", err)
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	precert, err := eca.CreatePrecertificate(ctx, testPrincipal{}, key.Public())
+	if err != nil {
+		t.Fatalf("CreatePrecertificate() = %v", err)
+	}
+	preChain := ctl.BuildCTChain(precert.PreCert, precert.CertChain)
+
+	// The SCT verifies against the certificate it is embedded in
+	v, err := ctl.WithSCTVerification(c)
+	if err != nil {
+		t.Fatalf("WithSCTVerification() = %v", err)
+	}
+	logs, err := ctl.NewLogs(1, v)
+	if err != nil {
+		t.Fatalf("NewLogs() = %v", err)
+	}
+	scts, err := logs.AddPreChain(ctx, preChain)
+	if err != nil {
+		t.Fatalf("AddPreChain() = %v", err)
+	}
+	csc, err := eca.IssueFinalCertificate(ctx, precert, scts...)
+	if err != nil {
+		t.Fatalf("IssueFinalCertificate() = %v", err)
+	}
+	finalChain := ctl.BuildCTChain(csc.FinalCertificate, csc.FinalChain)
+	if err := logs.VerifyEmbeddedSCTs(finalChain, scts); err != nil {
+		t.Errorf("VerifyEmbeddedSCTs() = %v", err)
+	}
+	if _, err := c.AddChain(ctx, finalChain); err != nil {
+		t.Errorf("AddChain() = %v", err)
+	}
+
+	// Precertificates are only accepted by add-pre-chain
+	if _, err := c.AddChain(ctx, preChain); err == nil {
+		t.Error("AddChain() of a precertificate succeeded")
+	}
+	if _, err := c.AddPreChain(ctx, finalChain); err == nil {
+		t.Error("AddPreChain() of a certificate succeeded")
+	}
+
+	if l.Size() != 2 {
+		t.Errorf("got size %d, wanted 2", l.Size())
+	}
+	sth, err = c.GetSTH(ctx)
+	if err != nil {
+		t.Fatalf("GetSTH() = %v", err)
+	}
+	if sth.TreeSize != 2 {
+		t.Errorf("got tree size %d, wanted 2", sth.TreeSize)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package ctl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/certificate-transparency-go/client"
+)
+
+// CheckHealth checks that l is reachable by fetching its signed tree head, or
+// its checkpoint for static-ct-api logs. For a sharded log, every shard that
+// still accepts certificates is checked, since retired shards are no longer
+// submitted to.
+func CheckHealth(ctx context.Context, l Log) error {
+	switch l := l.(type) {
+	case *client.LogClient:
+		if _, err := l.GetSTH(ctx); err != nil {
+			return fmt.Errorf("fetching signed tree head: %w", err)
+		}
+		return nil
+	case *StaticLog:
+		_, err := l.Checkpoint(ctx)
+		return err
+	case *VerifyingLog:
+		return CheckHealth(ctx, l.Log)
+	case *RetryingLog:
+		return CheckHealth(ctx, l.Log)
+	case *ShardedLog:
+		return checkShards(ctx, l, time.Now())
+	}
+	return fmt.Errorf("unsupported CT log %T", l)
+}
+
+// checkShards checks every shard of l accepting certificates expiring after
+// now.
+func checkShards(ctx context.Context, l *ShardedLog, now time.Time) error {
+	var errs []error
+	checked := 0
+	for _, s := range l.Shards() {
+		if !s.NotAfterLimit.After(now) {
+			continue
+		}
+		checked++
+		if err := CheckHealth(ctx, s.Log); err != nil {
+			errs = append(errs, fmt.Errorf("shard until %v: %w", s.NotAfterLimit, err))
+		}
+	}
+	if checked == 0 {
+		return fmt.Errorf("%w: every shard has expired", ErrNoShard)
+	}
+	return errors.Join(errs...)
+}
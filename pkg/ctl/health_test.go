@@ -0,0 +1,54 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctl
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCheckHealthShardedLog(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	healthy := fakeStaticLog(t, "")
+	unhealthy := fakeStaticLog(t, "")
+	unhealthy.monitoringURL += "/missing"
+
+	newSharded := func(retired, current Log) *ShardedLog {
+		l, err := NewShardedLog(
+			Shard{Log: retired, NotAfterStart: now.Add(-2 * time.Hour), NotAfterLimit: now.Add(-time.Hour)},
+			Shard{Log: current, NotAfterStart: now.Add(-time.Hour), NotAfterLimit: now.Add(time.Hour)},
+		)
+		if err != nil {
+			t.Fatalf("NewShardedLog() = %v", err)
+		}
+		return l
+	}
+
+	// Retired shards are not checked
+	if err := CheckHealth(ctx, newSharded(unhealthy, healthy)); err != nil {
+		t.Errorf("CheckHealth() = %v", err)
+	}
+	// Shards accepting certificates are
+	if err := CheckHealth(ctx, newSharded(healthy, unhealthy)); err == nil {
+		t.Error("CheckHealth() succeeded with an unhealthy current shard")
+	}
+	// A log whose shards have all expired can't accept certificates
+	if err := checkShards(ctx, newSharded(healthy, healthy), now.Add(2*time.Hour)); !errors.Is(err, ErrNoShard) {
+		t.Errorf("checkShards() = %v, wanted %v", err, ErrNoShard)
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package ctl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	ct "github.com/google/certificate-transparency-go"
+	"github.com/google/certificate-transparency-go/client"
+
+	"github.com/sigstore/fulcio/pkg/log"
+)
+
+// Log is a CT log that certificate chains are submitted to. It is implemented
+// by *client.LogClient.
+type Log interface {
+	AddChain(ctx context.Context, chain []ct.ASN1Cert) (*ct.SignedCertificateTimestamp, error)
+	AddPreChain(ctx context.Context, chain []ct.ASN1Cert) (*ct.SignedCertificateTimestamp, error)
+}
+
+// LogClients returns the RFC 6962 API clients of l, which for a sharded log
+// are the clients of its shards, and for a static-ct-api log the client of
+// its submission prefix.
+func LogClients(l Log) []*client.LogClient {
+	switch l := l.(type) {
+	case *client.LogClient:
+		return []*client.LogClient{l}
+	case *VerifyingLog:
+		if l.client != nil {
+			return []*client.LogClient{l.client}
+		}
+		return LogClients(l.Log)
+	case *RetryingLog:
+		return LogClients(l.Log)
+	case *StaticLog:
+		return []*client.LogClient{l.client}
+	case *ShardedLog:
+		var clients []*client.LogClient
+		for _, s := range l.Shards() {
+			clients = append(clients, LogClients(s.Log)...)
+		}
+		return clients
+	}
+	return nil
+}
+
+// ErrQuorumNotReached is returned when fewer logs than the quorum returned an
+// SCT for a chain.
+var ErrQuorumNotReached = errors.New("too few CT logs returned an SCT")
+
+// Logs submits chains to several CT logs concurrently, so that certificates
+// are logged by more than one log operator.
+type Logs struct {
+	logs   []Log
+	quorum int
+}
+
+// NewLogs returns Logs submitting to every log and requiring an SCT from at
+// least quorum of them. A quorum of 0 requires an SCT from every log.
+func NewLogs(quorum int, logs ...Log) (*Logs, error) {
+	if len(logs) == 0 {
+		return nil, errors.New("no CT logs")
+	}
+	if quorum == 0 {
+		quorum = len(logs)
+	}
+	if quorum < 0 || quorum > len(logs) {
+		return nil, fmt.Errorf("quorum %d must be between 1 and the number of CT logs, %d", quorum, len(logs))
+	}
+	return &Logs{logs: logs, quorum: quorum}, nil
+}
+
+// Members returns the logs that chains are submitted to.
+func (l *Logs) Members() []Log {
+	return l.logs
+}
+
+// Quorum returns the number of logs that must return an SCT for a chain.
+func (l *Logs) Quorum() int {
+	return l.quorum
+}
+
+// AddChain submits a certificate chain to every log and returns the SCTs of
+// the logs that accepted it, in the order of the logs.
+func (l *Logs) AddChain(ctx context.Context, chain []ct.ASN1Cert) ([]*ct.SignedCertificateTimestamp, error) {
+	return l.submit(ctx, func(log Log) (*ct.SignedCertificateTimestamp, error) {
+		return log.AddChain(ctx, chain)
+	})
+}
+
+// AddPreChain submits a precertificate chain to every log and returns the
+// SCTs of the logs that accepted it, in the order of the logs.
+func (l *Logs) AddPreChain(ctx context.Context, chain []ct.ASN1Cert) ([]*ct.SignedCertificateTimestamp, error) {
+	return l.submit(ctx, func(log Log) (*ct.SignedCertificateTimestamp, error) {
+		return log.AddPreChain(ctx, chain)
+	})
+}
+
+// submit waits for every log rather than returning once the quorum is
+// reached, so that certificates include as many SCTs as possible. An SCT that
+// fails verification fails the submission even if the quorum is reached, since
+// it indicates a misconfigured or misbehaving log.
+func (l *Logs) submit(ctx context.Context, add func(Log) (*ct.SignedCertificateTimestamp, error)) ([]*ct.SignedCertificateTimestamp, error) {
+	scts := make([]*ct.SignedCertificateTimestamp, len(l.logs))
+	errs := make([]error, len(l.logs))
+	var wg sync.WaitGroup
+	for i, ctLog := range l.logs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			scts[i], errs[i] = add(ctLog)
+		}()
+	}
+	wg.Wait()
+
+	var accepted []*ct.SignedCertificateTimestamp
+	for i, sct := range scts {
+		if errors.Is(errs[i], ErrSCTVerification) {
+			return nil, fmt.Errorf("CT log %d: %w", i, errs[i])
+		}
+		if errs[i] != nil {
+			log.ContextLogger(ctx).Warnw("CT log submission failed", "log", i, "error", errs[i])
+			continue
+		}
+		accepted = append(accepted, sct)
+	}
+	if len(accepted) < l.quorum {
+		return nil, fmt.Errorf("%w: %d of %d required: %w", ErrQuorumNotReached, len(accepted), l.quorum, errors.Join(errs...))
+	}
+	return accepted, nil
+}
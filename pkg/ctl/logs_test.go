@@ -0,0 +1,100 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctl
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	ct "github.com/google/certificate-transparency-go"
+)
+
+// fakeLog returns an SCT with its ID as the log ID, or err if set
+type fakeLog struct {
+	id  byte
+	err error
+}
+
+func (l fakeLog) add() (*ct.SignedCertificateTimestamp, error) {
+	if l.err != nil {
+		return nil, l.err
+	}
+	return &ct.SignedCertificateTimestamp{LogID: ct.LogID{KeyID: [32]byte{l.id}}}, nil
+}
+
+func (l fakeLog) AddChain(context.Context, []ct.ASN1Cert) (*ct.SignedCertificateTimestamp, error) {
+	return l.add()
+}
+
+func (l fakeLog) AddPreChain(context.Context, []ct.ASN1Cert) (*ct.SignedCertificateTimestamp, error) {
+	return l.add()
+}
+
+func TestNewLogs(t *testing.T) {
+	logs := []Log{fakeLog{id: 1}, fakeLog{id: 2}}
+	tests := map[string]struct {
+		quorum  int
+		logs    []Log
+		wantErr bool
+	}{
+		"quorum of every log": {0, logs, false},
+		"quorum of one":       {1, logs, false},
+		"quorum of two":       {2, logs, false},
+		"quorum too large":    {3, logs, true},
+		"negative quorum":     {-1, logs, true},
+		"no logs":             {0, nil, true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewLogs(test.quorum, test.logs...)
+			if (err != nil) != test.wantErr {
+				t.Errorf("NewLogs() = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestLogsSubmit(t *testing.T) {
+	unavailable := errors.New("unavailable")
+	logs := []Log{fakeLog{id: 1}, fakeLog{id: 2, err: unavailable}, fakeLog{id: 3}}
+
+	l, err := NewLogs(2, logs...)
+	if err != nil {
+		t.Fatalf("NewLogs() = %v", err)
+	}
+	for name, add := range map[string]func(context.Context, []ct.ASN1Cert) ([]*ct.SignedCertificateTimestamp, error){
+		"AddChain":    l.AddChain,
+		"AddPreChain": l.AddPreChain,
+	} {
+		scts, err := add(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("%s() = %v", name, err)
+		}
+		// The SCTs of the logs that accepted the chain, in order
+		if len(scts) != 2 || scts[0].LogID.KeyID[0] != 1 || scts[1].LogID.KeyID[0] != 3 {
+			t.Errorf("%s() returned unexpected SCTs %v", name, scts)
+		}
+	}
+
+	l, err = NewLogs(0, logs...)
+	if err != nil {
+		t.Fatalf("NewLogs() = %v", err)
+	}
+	_, err = l.AddPreChain(context.Background(), nil)
+	if !errors.Is(err, ErrQuorumNotReached) || !errors.Is(err, unavailable) {
+		t.Errorf("AddPreChain() = %v, wanted quorum error wrapping the log error", err)
+	}
+}
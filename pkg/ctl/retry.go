@@ -0,0 +1,316 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package ctl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+
+	ct "github.com/google/certificate-transparency-go"
+	"github.com/google/certificate-transparency-go/client"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/sigstore/fulcio/pkg/log"
+)
+
+// Defaults of a RetryingLog.
+const (
+	DefaultMaxAttempts      = 3
+	DefaultInitialBackoff   = 100 * time.Millisecond
+	DefaultMaxBackoff       = 2 * time.Second
+	DefaultAttemptTimeout   = 10 * time.Second
+	DefaultBreakerThreshold = 5
+	DefaultBreakerCooldown  = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned without contacting a log while its circuit
+// breaker is open.
+var ErrCircuitOpen = errors.New("CT log circuit breaker is open")
+
+// States of a circuit breaker, as reported by the
+// fulcio_ct_log_circuit_breaker_state metric.
+const (
+	breakerClosed = iota
+	breakerHalfOpen
+	breakerOpen
+)
+
+var (
+	metricAttempts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "fulcio_ct_log_attempts",
+		Help: "The total number of attempts to submit a chain to a CT log, by log and result",
+	}, []string{"log", "result"})
+	metricAttemptLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "fulcio_ct_log_attempt_latency_seconds",
+		Help: "The latency of attempts to submit a chain to a CT log, by log",
+	}, []string{"log"})
+	metricBreakerState = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "fulcio_ct_log_circuit_breaker_state",
+		Help: "The state of the circuit breaker of a CT log: 0 is closed, 1 is half-open and 2 is open",
+	}, []string{"log"})
+)
+
+// outcome of an attempt, as seen by the circuit breaker
+type outcome int
+
+const (
+	outcomeSuccess outcome = iota
+	outcomeFailure
+	// the attempt says nothing about the health of the log, such as when the
+	// request was cancelled by the caller
+	outcomeNeutral
+)
+
+// RetryOption configures a RetryingLog.
+type RetryOption func(*RetryingLog)
+
+// WithMaxAttempts sets the number of attempts made to submit a chain,
+// including the first. At least one attempt is always made.
+func WithMaxAttempts(n int) RetryOption {
+	return func(r *RetryingLog) {
+		r.maxAttempts = n
+	}
+}
+
+// WithBackoff sets the delay before the first retry, which doubles for every
+// following retry up to limit. Each delay is jittered by up to half.
+func WithBackoff(initial, limit time.Duration) RetryOption {
+	return func(r *RetryingLog) {
+		r.initialBackoff = initial
+		r.maxBackoff = limit
+	}
+}
+
+// WithAttemptTimeout sets the time after which a single attempt is abandoned.
+func WithAttemptTimeout(d time.Duration) RetryOption {
+	return func(r *RetryingLog) {
+		r.attemptTimeout = d
+	}
+}
+
+// WithCircuitBreaker opens the circuit breaker after threshold consecutive
+// failed attempts, rejecting submissions for cooldown before letting a single
+// attempt through to probe the log. A threshold of 0 disables the breaker.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) RetryOption {
+	return func(r *RetryingLog) {
+		r.breakerThreshold = threshold
+		r.breakerCooldown = cooldown
+	}
+}
+
+// RetryingLog retries failed submissions to a log with exponential backoff,
+// and sheds load with a circuit breaker while the log is unhealthy, so that
+// requests fail quickly rather than waiting on a log that is down.
+//
+// Each attempt is bounded by the attempt timeout. This also bounds the
+// retries of the RFC 6962 client, which retries unavailable logs until its
+// context is done.
+type RetryingLog struct {
+	Log
+	name string
+
+	maxAttempts      int
+	initialBackoff   time.Duration
+	maxBackoff       time.Duration
+	attemptTimeout   time.Duration
+	breakerThreshold int
+	breakerCooldown  time.Duration
+
+	// now is replaced in tests
+	now func() time.Time
+
+	mu       sync.Mutex
+	state    int
+	failures int
+	openedAt time.Time
+}
+
+// NewRetryingLog returns a RetryingLog submitting to l. name identifies the
+// log in metrics and logs.
+func NewRetryingLog(name string, l Log, opts ...RetryOption) *RetryingLog {
+	r := &RetryingLog{
+		Log:              l,
+		name:             name,
+		maxAttempts:      DefaultMaxAttempts,
+		initialBackoff:   DefaultInitialBackoff,
+		maxBackoff:       DefaultMaxBackoff,
+		attemptTimeout:   DefaultAttemptTimeout,
+		breakerThreshold: DefaultBreakerThreshold,
+		breakerCooldown:  DefaultBreakerCooldown,
+		now:              time.Now,
+	}
+	for _, o := range opts {
+		o(r)
+	}
+	r.maxAttempts = max(r.maxAttempts, 1)
+	metricBreakerState.WithLabelValues(name).Set(breakerClosed)
+	return r
+}
+
+// AddChain submits a certificate chain to the log, retrying failures.
+func (r *RetryingLog) AddChain(ctx context.Context, chain []ct.ASN1Cert) (*ct.SignedCertificateTimestamp, error) {
+	return r.submit(ctx, func(ctx context.Context) (*ct.SignedCertificateTimestamp, error) {
+		return r.Log.AddChain(ctx, chain)
+	})
+}
+
+// AddPreChain submits a precertificate chain to the log, retrying failures.
+func (r *RetryingLog) AddPreChain(ctx context.Context, chain []ct.ASN1Cert) (*ct.SignedCertificateTimestamp, error) {
+	return r.submit(ctx, func(ctx context.Context) (*ct.SignedCertificateTimestamp, error) {
+		return r.Log.AddPreChain(ctx, chain)
+	})
+}
+
+func (r *RetryingLog) submit(ctx context.Context, add func(context.Context) (*ct.SignedCertificateTimestamp, error)) (*ct.SignedCertificateTimestamp, error) {
+	var err error
+	for attempt := 0; attempt < r.maxAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("%w, last error: %w", ctx.Err(), err)
+			case <-time.After(r.backoff(attempt)):
+			}
+		}
+		if !r.allow() {
+			metricAttempts.WithLabelValues(r.name, "rejected").Inc()
+			return nil, fmt.Errorf("%w: %s", ErrCircuitOpen, r.name)
+		}
+
+		var sct *ct.SignedCertificateTimestamp
+		sct, err = r.attempt(ctx, add)
+		if err == nil {
+			return sct, nil
+		}
+		if !retryable(ctx, err) {
+			return nil, err
+		}
+		log.ContextLogger(ctx).Warnw("CT log submission attempt failed", "log", r.name, "attempt", attempt+1, "error", err)
+	}
+	return nil, fmt.Errorf("%d attempts failed: %w", r.maxAttempts, err)
+}
+
+func (r *RetryingLog) attempt(ctx context.Context, add func(context.Context) (*ct.SignedCertificateTimestamp, error)) (*ct.SignedCertificateTimestamp, error) {
+	attemptCtx, cancel := context.WithTimeout(ctx, r.attemptTimeout)
+	defer cancel()
+
+	start := time.Now()
+	sct, err := add(attemptCtx)
+	metricAttemptLatency.WithLabelValues(r.name).Observe(time.Since(start).Seconds())
+
+	switch {
+	case err == nil:
+		metricAttempts.WithLabelValues(r.name, "success").Inc()
+		r.record(outcomeSuccess)
+	case ctx.Err() != nil:
+		metricAttempts.WithLabelValues(r.name, "failure").Inc()
+		r.record(outcomeNeutral)
+	case !retryable(ctx, err):
+		// the log responded, but rejected the chain or returned a bad SCT
+		metricAttempts.WithLabelValues(r.name, "failure").Inc()
+		r.record(outcomeSuccess)
+	default:
+		metricAttempts.WithLabelValues(r.name, "failure").Inc()
+		r.record(outcomeFailure)
+	}
+	return sct, err
+}
+
+// backoff returns the jittered delay before the given attempt.
+func (r *RetryingLog) backoff(attempt int) time.Duration {
+	d := r.initialBackoff
+	for i := 1; i < attempt && d < r.maxBackoff; i++ {
+		d *= 2
+	}
+	d = min(d, r.maxBackoff)
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+// retryable returns whether a failed attempt may succeed if repeated.
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, ErrSCTVerification) || errors.Is(err, ErrNoShard) || errors.Is(err, ErrNoLeafIndex) {
+		return false
+	}
+	// The log rejected the chain
+	var rspErr client.RspError
+	if errors.As(err, &rspErr) && rspErr.StatusCode >= http.StatusBadRequest && rspErr.StatusCode < http.StatusInternalServerError &&
+		rspErr.StatusCode != http.StatusRequestTimeout && rspErr.StatusCode != http.StatusTooManyRequests {
+		return false
+	}
+	return true
+}
+
+// allow returns whether an attempt may be made. Once the cooldown of an open
+// breaker has passed, a single attempt is let through to probe the log.
+func (r *RetryingLog) allow() bool {
+	if r.breakerThreshold == 0 {
+		return true
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch r.state {
+	case breakerOpen:
+		if r.now().Sub(r.openedAt) < r.breakerCooldown {
+			return false
+		}
+		r.setState(breakerHalfOpen)
+		return true
+	case breakerHalfOpen:
+		return false
+	}
+	return true
+}
+
+func (r *RetryingLog) record(o outcome) {
+	if r.breakerThreshold == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch o {
+	case outcomeSuccess:
+		r.failures = 0
+		if r.state != breakerClosed {
+			log.Logger.Infow("CT log circuit breaker closed", "log", r.name)
+		}
+		r.setState(breakerClosed)
+	case outcomeFailure:
+		r.failures++
+		if r.state == breakerHalfOpen || (r.state == breakerClosed && r.failures >= r.breakerThreshold) {
+			log.Logger.Warnw("CT log circuit breaker opened", "log", r.name, "consecutiveFailures", r.failures)
+			r.openedAt = r.now()
+			r.setState(breakerOpen)
+		}
+	case outcomeNeutral:
+		// Let the next attempt probe the log instead
+		if r.state == breakerHalfOpen {
+			r.setState(breakerOpen)
+		}
+	}
+}
+
+func (r *RetryingLog) setState(state int) {
+	r.state = state
+	metricBreakerState.WithLabelValues(r.name).Set(float64(state))
+}
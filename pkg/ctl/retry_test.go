@@ -0,0 +1,161 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ct "github.com/google/certificate-transparency-go"
+	"github.com/google/certificate-transparency-go/client"
+)
+
+// flakyLog fails the first failures calls with err
+type flakyLog struct {
+	failures int32
+	err      error
+	calls    atomic.Int32
+}
+
+func (l *flakyLog) add(ctx context.Context) (*ct.SignedCertificateTimestamp, error) {
+	if l.calls.Add(1) <= l.failures {
+		if l.err == nil {
+			// hang until the attempt times out
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return nil, l.err
+	}
+	return &ct.SignedCertificateTimestamp{}, nil
+}
+
+func (l *flakyLog) AddChain(ctx context.Context, _ []ct.ASN1Cert) (*ct.SignedCertificateTimestamp, error) {
+	return l.add(ctx)
+}
+
+func (l *flakyLog) AddPreChain(ctx context.Context, _ []ct.ASN1Cert) (*ct.SignedCertificateTimestamp, error) {
+	return l.add(ctx)
+}
+
+func TestRetryingLog(t *testing.T) {
+	unavailable := client.RspError{StatusCode: http.StatusServiceUnavailable, Err: errors.New("unavailable")}
+	rejected := client.RspError{StatusCode: http.StatusBadRequest, Err: errors.New("bad chain")}
+
+	tests := map[string]struct {
+		failures  int32
+		err       error
+		wantCalls int32
+		wantErr   bool
+	}{
+		"succeeds first time":     {0, nil, 1, false},
+		"succeeds after retries":  {2, unavailable, 3, false},
+		"fails every attempt":     {3, unavailable, 3, true},
+		"attempt times out":       {1, nil, 2, false},
+		"rejected chain":          {1, rejected, 1, true},
+		"SCT fails verification":  {1, fmt.Errorf("%w: bad signature", ErrSCTVerification), 1, true},
+		"wrapped transient error": {1, fmt.Errorf("posting: %w", unavailable), 2, false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			l := &flakyLog{failures: test.failures, err: test.err}
+			r := NewRetryingLog(name, l, WithBackoff(time.Millisecond, 5*time.Millisecond), WithAttemptTimeout(10*time.Millisecond), WithCircuitBreaker(0, 0))
+			_, err := r.AddPreChain(context.Background(), nil)
+			if (err != nil) != test.wantErr {
+				t.Errorf("AddPreChain() = %v, wanted error: %v", err, test.wantErr)
+			}
+			if got := l.calls.Load(); got != test.wantCalls {
+				t.Errorf("got %d calls, wanted %d", got, test.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryingLogCancelled(t *testing.T) {
+	l := &flakyLog{failures: 10, err: errors.New("unavailable")}
+	r := NewRetryingLog("cancelled", l, WithMaxAttempts(10), WithBackoff(time.Hour, time.Hour))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := r.AddChain(ctx, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("AddChain() = %v, wanted %v", err, context.DeadlineExceeded)
+	}
+	if got := l.calls.Load(); got != 1 {
+		t.Errorf("got %d calls, wanted 1", got)
+	}
+}
+
+func TestRetryingLogNoAttempts(t *testing.T) {
+	l := &flakyLog{}
+	r := NewRetryingLog("no-attempts", l, WithMaxAttempts(0))
+	if _, err := r.AddChain(context.Background(), nil); err != nil {
+		t.Errorf("AddChain() = %v", err)
+	}
+	if got := l.calls.Load(); got != 1 {
+		t.Errorf("got %d calls, wanted 1", got)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	l := &flakyLog{failures: 3, err: errors.New("unavailable")}
+	now := time.Now()
+	r := NewRetryingLog("breaker", l, WithMaxAttempts(1), WithCircuitBreaker(2, time.Minute))
+	r.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// Two consecutive failures open the breaker
+	for i := 0; i < 2; i++ {
+		if _, err := r.AddChain(ctx, nil); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("AddChain() = %v, wanted log failure", err)
+		}
+	}
+	if _, err := r.AddChain(ctx, nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("AddChain() = %v, wanted %v", err, ErrCircuitOpen)
+	}
+	if got := l.calls.Load(); got != 2 {
+		t.Fatalf("got %d calls while open, wanted 2", got)
+	}
+
+	// After the cooldown a failing probe reopens the breaker
+	now = now.Add(time.Minute)
+	if _, err := r.AddChain(ctx, nil); err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("AddChain() = %v, wanted log failure", err)
+	}
+	if _, err := r.AddChain(ctx, nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("AddChain() = %v, wanted %v", err, ErrCircuitOpen)
+	}
+
+	// A successful probe closes it
+	now = now.Add(time.Minute)
+	for i := 0; i < 2; i++ {
+		if _, err := r.AddChain(ctx, nil); err != nil {
+			t.Fatalf("AddChain() = %v", err)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	r := NewRetryingLog("backoff", nil, WithBackoff(100*time.Millisecond, time.Second))
+	for attempt, want := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 400 * time.Millisecond, 10: time.Second} {
+		for i := 0; i < 10; i++ {
+			if got := r.backoff(attempt); got < want/2 || got > want {
+				t.Errorf("backoff(%d) = %v, wanted between %v and %v", attempt, got, want/2, want)
+			}
+		}
+	}
+}
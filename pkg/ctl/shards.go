@@ -0,0 +1,112 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package ctl
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	ct "github.com/google/certificate-transparency-go"
+)
+
+// ErrNoShard is returned when no shard of a log accepts certificates expiring
+// at the time of a submitted certificate.
+var ErrNoShard = errors.New("no CT log shard covers the certificate expiry")
+
+// Shard is a temporal shard of a CT log, which only accepts certificates
+// expiring in [NotAfterStart, NotAfterLimit).
+type Shard struct {
+	Log           Log
+	NotAfterStart time.Time
+	NotAfterLimit time.Time
+}
+
+// ShardedLog is a CT log split into temporal shards. Each chain is submitted
+// to the shard whose window covers the expiry of its certificate, so new
+// shards are used as soon as certificates expire in their window, and
+// retired shards are no longer used once certificates outlive them.
+type ShardedLog struct {
+	shards []Shard
+}
+
+// NewShardedLog returns a log submitting to the shards, whose windows must not
+// overlap.
+func NewShardedLog(shards ...Shard) (*ShardedLog, error) {
+	if len(shards) == 0 {
+		return nil, errors.New("no CT log shards")
+	}
+	sorted := make([]Shard, len(shards))
+	copy(sorted, shards)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].NotAfterStart.Before(sorted[j].NotAfterStart)
+	})
+	for i, s := range sorted {
+		if !s.NotAfterStart.Before(s.NotAfterLimit) {
+			return nil, fmt.Errorf("shard window starting %v must end after it starts", s.NotAfterStart)
+		}
+		if i > 0 && s.NotAfterStart.Before(sorted[i-1].NotAfterLimit) {
+			return nil, fmt.Errorf("shard windows starting %v and %v overlap", sorted[i-1].NotAfterStart, s.NotAfterStart)
+		}
+	}
+	return &ShardedLog{shards: sorted}, nil
+}
+
+// Shards returns the shards of the log, ordered by their windows.
+func (l *ShardedLog) Shards() []Shard {
+	return l.shards
+}
+
+// shardFor returns the shard accepting certificates expiring at notAfter.
+func (l *ShardedLog) shardFor(notAfter time.Time) (Log, error) {
+	for _, s := range l.shards {
+		if !notAfter.Before(s.NotAfterStart) && notAfter.Before(s.NotAfterLimit) {
+			return s.Log, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %v", ErrNoShard, notAfter)
+}
+
+// chainShard returns the shard accepting the first certificate of chain.
+func (l *ShardedLog) chainShard(chain []ct.ASN1Cert) (Log, error) {
+	if len(chain) == 0 {
+		return nil, errors.New("empty chain")
+	}
+	cert, err := x509.ParseCertificate(chain[0].Data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing certificate: %w", err)
+	}
+	return l.shardFor(cert.NotAfter)
+}
+
+func (l *ShardedLog) AddChain(ctx context.Context, chain []ct.ASN1Cert) (*ct.SignedCertificateTimestamp, error) {
+	shard, err := l.chainShard(chain)
+	if err != nil {
+		return nil, err
+	}
+	return shard.AddChain(ctx, chain)
+}
+
+func (l *ShardedLog) AddPreChain(ctx context.Context, chain []ct.ASN1Cert) (*ct.SignedCertificateTimestamp, error) {
+	shard, err := l.chainShard(chain)
+	if err != nil {
+		return nil, err
+	}
+	return shard.AddPreChain(ctx, chain)
+}
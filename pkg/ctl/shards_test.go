@@ -0,0 +1,113 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctl
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	ct "github.com/google/certificate-transparency-go"
+)
+
+// chainExpiring returns a chain of a self-signed certificate expiring at notAfter
+func chainExpiring(t *testing.T, notAfter time.Time) []ct.ASN1Cert {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    notAfter.Add(-10 * time.Minute),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, priv.Public(), priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return []ct.ASN1Cert{{Data: der}}
+}
+
+func TestShardedLog(t *testing.T) {
+	y2025 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	y2026 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	y2027 := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	l, err := NewShardedLog(
+		Shard{Log: fakeLog{id: 26}, NotAfterStart: y2026, NotAfterLimit: y2027},
+		Shard{Log: fakeLog{id: 25}, NotAfterStart: y2025, NotAfterLimit: y2026},
+	)
+	if err != nil {
+		t.Fatalf("NewShardedLog() = %v", err)
+	}
+
+	tests := map[string]struct {
+		notAfter time.Time
+		wantLog  byte
+		wantErr  error
+	}{
+		"first shard":                 {y2025.Add(time.Hour), 25, nil},
+		"start of window is included": {y2026.Truncate(time.Second), 26, nil},
+		"end of window is excluded":   {y2027, 0, ErrNoShard},
+		"before every shard":          {y2025.Add(-time.Hour), 0, ErrNoShard},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			chain := chainExpiring(t, test.notAfter)
+			for _, add := range []func(context.Context, []ct.ASN1Cert) (*ct.SignedCertificateTimestamp, error){l.AddChain, l.AddPreChain} {
+				sct, err := add(context.Background(), chain)
+				if !errors.Is(err, test.wantErr) {
+					t.Fatalf("got error %v, wanted %v", err, test.wantErr)
+				}
+				if err == nil && sct.LogID.KeyID[0] != test.wantLog {
+					t.Errorf("submitted to shard %d, wanted %d", sct.LogID.KeyID[0], test.wantLog)
+				}
+			}
+		})
+	}
+}
+
+func TestNewShardedLog(t *testing.T) {
+	y2025 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	y2026 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	y2027 := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		shards  []Shard
+		wantErr bool
+	}{
+		"adjacent windows":    {[]Shard{{fakeLog{}, y2025, y2026}, {fakeLog{}, y2026, y2027}}, false},
+		"gap between windows": {[]Shard{{fakeLog{}, y2025, y2026.Add(-time.Hour)}, {fakeLog{}, y2026, y2027}}, false},
+		"overlapping windows": {[]Shard{{fakeLog{}, y2025, y2027}, {fakeLog{}, y2026, y2027}}, true},
+		"empty window":        {[]Shard{{fakeLog{}, y2026, y2026}}, true},
+		"reversed window":     {[]Shard{{fakeLog{}, y2027, y2026}}, true},
+		"no shards":           {nil, true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewShardedLog(test.shards...)
+			if (err != nil) != test.wantErr {
+				t.Errorf("NewShardedLog() = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package ctl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	ct "github.com/google/certificate-transparency-go"
+	"github.com/google/certificate-transparency-go/client"
+)
+
+// Types of CT log APIs.
+const (
+	// LogTypeRFC6962 logs implement the RFC 6962 API
+	LogTypeRFC6962 = "rfc6962"
+	// LogTypeStaticCTAPI logs implement the static-ct-api, as specified at
+	// https://c2sp.org/static-ct-api, such as tiled logs
+	LogTypeStaticCTAPI = "static-ct-api"
+)
+
+// leafIndexExtensionType is the type of the leaf_index SCT extension of the
+// static-ct-api.
+const leafIndexExtensionType = 0
+
+// ErrNoLeafIndex is returned when an SCT from a static-ct-api log has no valid
+// leaf_index extension.
+var ErrNoLeafIndex = errors.New("SCT has no leaf index")
+
+// LeafIndex returns the index of the log entry that an SCT from a
+// static-ct-api log was issued for, from its leaf_index extension.
+func LeafIndex(sct *ct.SignedCertificateTimestamp) (uint64, error) {
+	// Extensions are encoded as struct { uint8 type; opaque data<0..2^16-1> }
+	exts := sct.Extensions
+	for len(exts) > 0 {
+		if len(exts) < 3 {
+			return 0, fmt.Errorf("%w: truncated extensions", ErrNoLeafIndex)
+		}
+		extType, length := exts[0], int(exts[1])<<8|int(exts[2])
+		if len(exts) < 3+length {
+			return 0, fmt.Errorf("%w: truncated extensions", ErrNoLeafIndex)
+		}
+		data := exts[3 : 3+length]
+		exts = exts[3+length:]
+		if extType != leafIndexExtensionType {
+			continue
+		}
+		// The index is a uint40
+		if len(data) != 5 {
+			return 0, fmt.Errorf("%w: leaf index is %d bytes, expected 5", ErrNoLeafIndex, len(data))
+		}
+		var index uint64
+		for _, b := range data {
+			index = index<<8 | uint64(b)
+		}
+		return index, nil
+	}
+	return 0, ErrNoLeafIndex
+}
+
+// StaticLog submits chains to a log implementing the static-ct-api. Chains
+// are submitted with the RFC 6962 add-chain and add-pre-chain endpoints under
+// the submission prefix of the log, and every SCT must identify its entry
+// with a leaf index. The log serves a checkpoint under its monitoring prefix
+// instead of the RFC 6962 get-sth endpoint.
+type StaticLog struct {
+	// submission is client without its public key, since SCTs are verified
+	// by a VerifyingLog
+	submission    *client.LogClient
+	client        *client.LogClient
+	monitoringURL string
+	httpClient    *http.Client
+}
+
+// NewStaticLog returns a StaticLog submitting with c, created with the
+// submission prefix of the log, and fetching checkpoints from monitoringURL
+// with httpClient.
+func NewStaticLog(c *client.LogClient, monitoringURL string, httpClient *http.Client) *StaticLog {
+	submission := *c
+	submission.Verifier = nil
+	return &StaticLog{
+		submission:    &submission,
+		client:        c,
+		monitoringURL: strings.TrimSuffix(monitoringURL, "/"),
+		httpClient:    httpClient,
+	}
+}
+
+// AddChain submits a certificate chain to the log.
+func (s *StaticLog) AddChain(ctx context.Context, chain []ct.ASN1Cert) (*ct.SignedCertificateTimestamp, error) {
+	return s.checkLeafIndex(s.submission.AddChain(ctx, chain))
+}
+
+// AddPreChain submits a precertificate chain to the log.
+func (s *StaticLog) AddPreChain(ctx context.Context, chain []ct.ASN1Cert) (*ct.SignedCertificateTimestamp, error) {
+	return s.checkLeafIndex(s.submission.AddPreChain(ctx, chain))
+}
+
+func (s *StaticLog) checkLeafIndex(sct *ct.SignedCertificateTimestamp, err error) (*ct.SignedCertificateTimestamp, error) {
+	if err != nil {
+		return nil, err
+	}
+	if _, err := LeafIndex(sct); err != nil {
+		return nil, fmt.Errorf("static-ct-api log %s: %w", s.client.BaseURI(), err)
+	}
+	return sct, nil
+}
+
+// Checkpoint returns the latest checkpoint of the log, a signed note
+// committing to the size and root hash of the tree.
+func (s *StaticLog) Checkpoint(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.monitoringURL+"/checkpoint", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching checkpoint: got HTTP status %q", resp.Status)
+	}
+	if len(body) == 0 {
+		return nil, errors.New("empty checkpoint")
+	}
+	return body, nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	ct "github.com/google/certificate-transparency-go"
+	"github.com/google/certificate-transparency-go/client"
+	"github.com/google/certificate-transparency-go/jsonclient"
+)
+
+func TestLeafIndex(t *testing.T) {
+	tests := map[string]struct {
+		extensions []byte
+		want       uint64
+		wantErr    bool
+	}{
+		"leaf index":               {[]byte{0, 0, 5, 0, 0, 0, 1, 2}, 258, false},
+		"after another extension":  {[]byte{7, 0, 1, 42, 0, 0, 5, 1, 0, 0, 0, 0}, 1 << 32, false},
+		"no extensions":            {nil, 0, true},
+		"other extension only":     {[]byte{7, 0, 1, 42}, 0, true},
+		"leaf index of wrong size": {[]byte{0, 0, 4, 0, 0, 0, 1}, 0, true},
+		"truncated":                {[]byte{0, 0, 5, 0, 0}, 0, true},
+		"truncated header":         {[]byte{0, 0}, 0, true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := LeafIndex(&ct.SignedCertificateTimestamp{Extensions: test.extensions})
+			if test.wantErr {
+				if !errors.Is(err, ErrNoLeafIndex) {
+					t.Errorf("LeafIndex() = %v, wanted %v", err, ErrNoLeafIndex)
+				}
+				return
+			}
+			if err != nil || got != test.want {
+				t.Errorf("LeafIndex() = %d, %v, wanted %d", got, err, test.want)
+			}
+		})
+	}
+}
+
+// fakeStaticLog serves add-pre-chain responses with the given base64-encoded
+// SCT extensions, and a checkpoint under /monitor
+func fakeStaticLog(t *testing.T, extensions string) *StaticLog {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /submit/ct/v1/add-pre-chain", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `{
+			"sct_version": 0,
+			"id": "KHYaGJAn++880NYaAY12sFBXKcenQRvMvfYE9F1CYVM=",
+			"timestamp": 1337,
+			"extensions": %q,
+			"signature": "BAMARjBEAiAIc21J5ZbdKZHw5wLxCP+MhBEsV5+nfvGyakOIv6FOvAIgWYMZb6Pw///uiNM7QTg2Of1OqmK1GbeGuEl9VJN8v8c="
+		}`, extensions)
+	})
+	mux.HandleFunc("GET /monitor/checkpoint", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, "example.com/log\n1\nAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=\n\n— example.com/log signature\n")
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	httpClient := &http.Client{Timeout: 5 * time.Second}
+	c, err := client.New(srv.URL+"/submit", httpClient, jsonclient.Options{})
+	if err != nil {
+		t.Fatalf("client.New() = %v", err)
+	}
+	return NewStaticLog(c, srv.URL+"/monitor/", httpClient)
+}
+
+func TestStaticLog(t *testing.T) {
+	ctx := context.Background()
+	chain := chainExpiring(t, time.Now().Add(time.Hour))
+
+	l := fakeStaticLog(t, "AAAFAAAAAAc=")
+	sct, err := l.AddPreChain(ctx, chain)
+	if err != nil {
+		t.Fatalf("AddPreChain() = %v", err)
+	}
+	if index, err := LeafIndex(sct); err != nil || index != 7 {
+		t.Errorf("LeafIndex() = %d, %v, wanted 7", index, err)
+	}
+	if err := CheckHealth(ctx, NewRetryingLog("static", l)); err != nil {
+		t.Errorf("CheckHealth() = %v", err)
+	}
+	if clients := LogClients(l); len(clients) != 1 || clients[0].BaseURI() != l.client.BaseURI() {
+		t.Errorf("LogClients() = %v, wanted the submission client", clients)
+	}
+
+	// SCTs of static-ct-api logs must have a leaf index
+	l = fakeStaticLog(t, "")
+	if _, err := l.AddPreChain(ctx, chain); !errors.Is(err, ErrNoLeafIndex) {
+		t.Errorf("AddPreChain() = %v, wanted %v", err, ErrNoLeafIndex)
+	}
+
+	// Static logs don't serve get-sth
+	l.monitoringURL += "/missing"
+	if err := CheckHealth(ctx, l); err == nil {
+		t.Error("CheckHealth() succeeded without a checkpoint")
+	}
+}
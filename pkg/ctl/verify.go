@@ -0,0 +1,231 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package ctl
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"slices"
+
+	ct "github.com/google/certificate-transparency-go"
+	"github.com/google/certificate-transparency-go/client"
+	"github.com/google/certificate-transparency-go/ctutil"
+	"github.com/google/certificate-transparency-go/tls"
+	ctx509 "github.com/google/certificate-transparency-go/x509"
+	"github.com/google/certificate-transparency-go/x509util"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// ErrSCTVerification is returned when an SCT was not issued by the log it was
+// requested from, or does not verify against the certificate it is for.
+var ErrSCTVerification = errors.New("SCT verification failed")
+
+const (
+	verificationStageSubmission = "submission"
+	verificationStageEmbedded   = "embedded"
+)
+
+var metricSCTVerificationFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "fulcio_sct_verification_failures",
+	Help: "The total number of SCTs that failed local verification, by whether they were returned by a log or embedded in a certificate",
+}, []string{"stage"})
+
+// VerifyingLog verifies every SCT returned by a log against the public key of
+// the log and the submitted chain, so that an SCT from another log or for
+// another certificate is never embedded in or returned with a certificate.
+type VerifyingLog struct {
+	Log
+	verifier *ct.SignatureVerifier
+	logID    [sha256.Size]byte
+
+	// client is the client created with the log public key, if the log was
+	// created by WithSCTVerification
+	client *client.LogClient
+}
+
+// NewVerifyingLog returns a VerifyingLog verifying the SCTs returned by l
+// against pubKey.
+func NewVerifyingLog(l Log, pubKey crypto.PublicKey) (*VerifyingLog, error) {
+	verifier, err := ct.NewSignatureVerifier(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("creating SCT verifier: %w", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling CT log public key: %w", err)
+	}
+	return &VerifyingLog{Log: l, verifier: verifier, logID: sha256.Sum256(der)}, nil
+}
+
+// WithSCTVerification returns l wrapped in a VerifyingLog if a public key is
+// configured for its client, and l otherwise. l is a *client.LogClient or a
+// *StaticLog.
+func WithSCTVerification(l Log) (Log, error) {
+	switch l := l.(type) {
+	case *client.LogClient:
+		if l.Verifier == nil {
+			return l, nil
+		}
+		// The client would otherwise verify SCT signatures itself, and report
+		// failures as any other error from the log
+		unverified := *l
+		unverified.Verifier = nil
+		v, err := NewVerifyingLog(&unverified, l.Verifier.PubKey)
+		if err != nil {
+			return nil, err
+		}
+		v.client = l
+		return v, nil
+	case *StaticLog:
+		if l.client.Verifier == nil {
+			return l, nil
+		}
+		return NewVerifyingLog(l, l.client.Verifier.PubKey)
+	}
+	return l, nil
+}
+
+// AddChain submits a certificate chain to the log and verifies the SCT.
+func (v *VerifyingLog) AddChain(ctx context.Context, chain []ct.ASN1Cert) (*ct.SignedCertificateTimestamp, error) {
+	sct, err := v.Log.AddChain(ctx, chain)
+	if err != nil {
+		return nil, err
+	}
+	if err := v.verify(chain, sct, false, verificationStageSubmission); err != nil {
+		return nil, err
+	}
+	return sct, nil
+}
+
+// AddPreChain submits a precertificate chain to the log and verifies the SCT.
+func (v *VerifyingLog) AddPreChain(ctx context.Context, chain []ct.ASN1Cert) (*ct.SignedCertificateTimestamp, error) {
+	sct, err := v.Log.AddPreChain(ctx, chain)
+	if err != nil {
+		return nil, err
+	}
+	if err := v.verify(chain, sct, false, verificationStageSubmission); err != nil {
+		return nil, err
+	}
+	return sct, nil
+}
+
+// verify checks that sct was issued by the log for chain[0], which is a
+// certificate with the SCT embedded in it if embedded is set.
+func (v *VerifyingLog) verify(chain []ct.ASN1Cert, sct *ct.SignedCertificateTimestamp, embedded bool, stage string) error {
+	err := v.verifySCT(chain, sct, embedded)
+	if err != nil {
+		metricSCTVerificationFailures.WithLabelValues(stage).Inc()
+		return fmt.Errorf("%w: %w", ErrSCTVerification, err)
+	}
+	return nil
+}
+
+func (v *VerifyingLog) verifySCT(chain []ct.ASN1Cert, sct *ct.SignedCertificateTimestamp, embedded bool) error {
+	if sct.LogID.KeyID != v.logID {
+		return fmt.Errorf("SCT log ID %x does not match the log public key %x", sct.LogID.KeyID, v.logID)
+	}
+	// Only the leaf and its issuer are needed to build the signed entry
+	certs := make([]*ctx509.Certificate, min(len(chain), 2))
+	for i := range certs {
+		cert, err := ctx509.ParseCertificate(chain[i].Data)
+		if ctx509.IsFatal(err) {
+			return fmt.Errorf("parsing chain[%d]: %w", i, err)
+		}
+		certs[i] = cert
+	}
+	return ctutil.VerifySCTWithVerifier(v.verifier, certs, sct, embedded)
+}
+
+// verifyingLogs returns the VerifyingLogs of l, which for a sharded log are
+// those of its shards.
+func verifyingLogs(l Log) []*VerifyingLog {
+	switch l := l.(type) {
+	case *VerifyingLog:
+		return []*VerifyingLog{l}
+	case *RetryingLog:
+		return verifyingLogs(l.Log)
+	case *ShardedLog:
+		var logs []*VerifyingLog
+		for _, s := range l.Shards() {
+			logs = append(logs, verifyingLogs(s.Log)...)
+		}
+		return logs
+	}
+	return nil
+}
+
+// VerifyEmbeddedSCTs checks that the certificate at chain[0] embeds exactly
+// scts, and that each embedded SCT from a log with a configured public key
+// verifies against the precertificate the certificate was issued from.
+func (l *Logs) VerifyEmbeddedSCTs(chain []ct.ASN1Cert, scts []*ct.SignedCertificateTimestamp) error {
+	if len(chain) == 0 {
+		return errors.New("empty certificate chain")
+	}
+	cert, err := ctx509.ParseCertificate(chain[0].Data)
+	if ctx509.IsFatal(err) {
+		return fmt.Errorf("parsing certificate: %w", err)
+	}
+	if embedded := len(cert.SCTList.SCTList); embedded != len(scts) {
+		return embeddedSCTError(fmt.Errorf("certificate embeds %d SCTs, expected %d", embedded, len(scts)))
+	}
+
+	returned := make(map[[sha256.Size]byte][][]byte, len(scts))
+	for _, sct := range scts {
+		b, err := tls.Marshal(*sct)
+		if err != nil {
+			return fmt.Errorf("marshaling SCT: %w", err)
+		}
+		returned[sct.LogID.KeyID] = append(returned[sct.LogID.KeyID], b)
+	}
+	verifiers := map[[sha256.Size]byte]*VerifyingLog{}
+	for _, member := range l.logs {
+		for _, v := range verifyingLogs(member) {
+			verifiers[v.logID] = v
+		}
+	}
+	for i := range cert.SCTList.SCTList {
+		serialized := cert.SCTList.SCTList[i]
+		sct, err := x509util.ExtractSCT(&serialized)
+		if err != nil {
+			return embeddedSCTError(fmt.Errorf("decoding embedded SCT %d: %w", i, err))
+		}
+		candidates := returned[sct.LogID.KeyID]
+		match := slices.IndexFunc(candidates, func(b []byte) bool { return bytes.Equal(b, serialized.Val) })
+		if match < 0 {
+			return embeddedSCTError(fmt.Errorf("embedded SCT %d was not returned by log %x", i, sct.LogID.KeyID))
+		}
+		returned[sct.LogID.KeyID] = slices.Delete(candidates, match, match+1)
+		if v, ok := verifiers[sct.LogID.KeyID]; ok {
+			if err := v.verify(chain, sct, true, verificationStageEmbedded); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// embeddedSCTError counts and wraps a failure to verify the SCTs embedded in a
+// certificate.
+func embeddedSCTError(err error) error {
+	metricSCTVerificationFailures.WithLabelValues(verificationStageEmbedded).Inc()
+	return fmt.Errorf("%w: %w", ErrSCTVerification, err)
+}
@@ -0,0 +1,245 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctl
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"testing"
+	"time"
+
+	ct "github.com/google/certificate-transparency-go"
+	"github.com/google/certificate-transparency-go/tls"
+
+	"github.com/sigstore/fulcio/pkg/ca/ephemeralca"
+)
+
+// signingLog returns SCTs signed with key
+type signingLog struct {
+	key *ecdsa.PrivateKey
+}
+
+func newSigningLog(t *testing.T) signingLog {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signingLog{key: key}
+}
+
+func (l signingLog) sign(chain []ct.ASN1Cert, etype ct.LogEntryType) (*ct.SignedCertificateTimestamp, error) {
+	leaf, err := ct.MerkleTreeLeafFromRawChain(chain, etype, 1337)
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalPKIXPublicKey(l.key.Public())
+	if err != nil {
+		return nil, err
+	}
+	sct := &ct.SignedCertificateTimestamp{
+		SCTVersion: ct.V1,
+		LogID:      ct.LogID{KeyID: sha256.Sum256(der)},
+		Timestamp:  1337,
+	}
+	input, err := ct.SerializeSCTSignatureInput(*sct, ct.LogEntry{Leaf: *leaf})
+	if err != nil {
+		return nil, err
+	}
+	sig, err := tls.CreateSignature(*l.key, tls.SHA256, input)
+	if err != nil {
+		return nil, err
+	}
+	sct.Signature = ct.DigitallySigned(sig)
+	return sct, nil
+}
+
+func (l signingLog) AddChain(_ context.Context, chain []ct.ASN1Cert) (*ct.SignedCertificateTimestamp, error) {
+	return l.sign(chain, ct.X509LogEntryType)
+}
+
+func (l signingLog) AddPreChain(_ context.Context, chain []ct.ASN1Cert) (*ct.SignedCertificateTimestamp, error) {
+	return l.sign(chain, ct.PrecertLogEntryType)
+}
+
+// wrongLog returns SCTs from another log for the submitted chain
+type wrongLog struct {
+	signingLog
+}
+
+// staleLog returns SCTs for a previously submitted chain
+type staleLog struct {
+	signingLog
+	chain []ct.ASN1Cert
+}
+
+func (l staleLog) AddPreChain(context.Context, []ct.ASN1Cert) (*ct.SignedCertificateTimestamp, error) {
+	return l.sign(l.chain, ct.PrecertLogEntryType)
+}
+
+type testPrincipal struct{}
+
+func (testPrincipal) Name(context.Context) string {
+	return "test@example.com"
+}
+
+func (testPrincipal) Embed(_ context.Context, cert *x509.Certificate) error {
+	cert.EmailAddresses = []string{"test@example.com"}
+	return nil
+}
+
+// precertChain returns a precertificate chain issued by eca
+func precertChain(t *testing.T, eca *ephemeralca.EphemeralCA) []ct.ASN1Cert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	precert, err := eca.CreatePrecertificate(context.Background(), testPrincipal{}, key.Public())
+	if err != nil {
+		t.Fatalf("CreatePrecertificate() = %v", err)
+	}
+	return BuildCTChain(precert.PreCert, precert.CertChain)
+}
+
+func TestVerifyingLog(t *testing.T) {
+	eca, err := ephemeralca.NewEphemeralCA()
+	if err != nil {
+		t.Fatalf("NewEphemeralCA() = %v", err)
+	}
+	signer := newSigningLog(t)
+
+	tests := map[string]struct {
+		log     Log
+		wantErr bool
+	}{
+		"valid SCT":          {signer, false},
+		"SCT from other log": {wrongLog{newSigningLog(t)}, true},
+		"SCT for other cert": {staleLog{signer, precertChain(t, eca)}, true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			v, err := NewVerifyingLog(test.log, signer.key.Public())
+			if err != nil {
+				t.Fatalf("NewVerifyingLog() = %v", err)
+			}
+			_, err = v.AddPreChain(context.Background(), precertChain(t, eca))
+			if test.wantErr && !errors.Is(err, ErrSCTVerification) {
+				t.Errorf("AddPreChain() = %v, wanted %v", err, ErrSCTVerification)
+			}
+			if !test.wantErr && err != nil {
+				t.Errorf("AddPreChain() = %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifyingLogAddChain(t *testing.T) {
+	signer := newSigningLog(t)
+	v, err := NewVerifyingLog(signer, signer.key.Public())
+	if err != nil {
+		t.Fatalf("NewVerifyingLog() = %v", err)
+	}
+	if _, err := v.AddChain(context.Background(), chainExpiring(t, time.Now().Add(time.Hour))); err != nil {
+		t.Errorf("AddChain() = %v", err)
+	}
+}
+
+func TestVerificationFailureFailsQuorum(t *testing.T) {
+	eca, err := ephemeralca.NewEphemeralCA()
+	if err != nil {
+		t.Fatalf("NewEphemeralCA() = %v", err)
+	}
+	signer := newSigningLog(t)
+	other := newSigningLog(t)
+	bad, err := NewVerifyingLog(wrongLog{other}, signer.key.Public())
+	if err != nil {
+		t.Fatalf("NewVerifyingLog() = %v", err)
+	}
+	logs, err := NewLogs(1, signer, bad)
+	if err != nil {
+		t.Fatalf("NewLogs() = %v", err)
+	}
+	if _, err := logs.AddPreChain(context.Background(), precertChain(t, eca)); !errors.Is(err, ErrSCTVerification) {
+		t.Errorf("AddPreChain() = %v, wanted %v", err, ErrSCTVerification)
+	}
+}
+
+func TestVerifyEmbeddedSCTs(t *testing.T) {
+	eca, err := ephemeralca.NewEphemeralCA()
+	if err != nil {
+		t.Fatalf("NewEphemeralCA() = %v", err)
+	}
+	signer := newSigningLog(t)
+	v, err := NewVerifyingLog(signer, signer.key.Public())
+	if err != nil {
+		t.Fatalf("NewVerifyingLog() = %v", err)
+	}
+	logs, err := NewLogs(1, v)
+	if err != nil {
+		t.Fatalf("NewLogs() = %v", err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	precert, err := eca.CreatePrecertificate(ctx, testPrincipal{}, key.Public())
+	if err != nil {
+		t.Fatalf("CreatePrecertificate() = %v", err)
+	}
+	scts, err := logs.AddPreChain(ctx, BuildCTChain(precert.PreCert, precert.CertChain))
+	if err != nil {
+		t.Fatalf("AddPreChain() = %v", err)
+	}
+	csc, err := eca.IssueFinalCertificate(ctx, precert, scts...)
+	if err != nil {
+		t.Fatalf("IssueFinalCertificate() = %v", err)
+	}
+	chain := BuildCTChain(csc.FinalCertificate, csc.FinalChain)
+	if err := logs.VerifyEmbeddedSCTs(chain, scts); err != nil {
+		t.Errorf("VerifyEmbeddedSCTs() = %v", err)
+	}
+
+	// An SCT for another precertificate is not embedded in the certificate
+	otherSCTs, err := logs.AddPreChain(ctx, precertChain(t, eca))
+	if err != nil {
+		t.Fatalf("AddPreChain() = %v", err)
+	}
+	if err := logs.VerifyEmbeddedSCTs(chain, otherSCTs); !errors.Is(err, ErrSCTVerification) {
+		t.Errorf("VerifyEmbeddedSCTs() = %v, wanted %v", err, ErrSCTVerification)
+	}
+	// Every SCT must be embedded
+	if err := logs.VerifyEmbeddedSCTs(chain, append(scts, otherSCTs...)); !errors.Is(err, ErrSCTVerification) {
+		t.Errorf("VerifyEmbeddedSCTs() = %v, wanted %v", err, ErrSCTVerification)
+	}
+
+	// The embedded SCTs must be the ones returned by the logs, even if as many
+	// are embedded
+	tampered, err := eca.IssueFinalCertificate(ctx, precert, otherSCTs...)
+	if err != nil {
+		t.Fatalf("IssueFinalCertificate() = %v", err)
+	}
+	tamperedChain := BuildCTChain(tampered.FinalCertificate, tampered.FinalChain)
+	if err := logs.VerifyEmbeddedSCTs(tamperedChain, scts); !errors.Is(err, ErrSCTVerification) {
+		t.Errorf("VerifyEmbeddedSCTs() = %v, wanted %v", err, ErrSCTVerification)
+	}
+}
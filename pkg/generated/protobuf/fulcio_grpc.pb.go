@@ -23,6 +23,7 @@ package protobuf
 
 import (
 	context "context"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -34,9 +35,16 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CA_CreateSigningCertificate_FullMethodName = "/dev.sigstore.fulcio.v2.CA/CreateSigningCertificate"
-	CA_GetTrustBundle_FullMethodName           = "/dev.sigstore.fulcio.v2.CA/GetTrustBundle"
-	CA_GetConfiguration_FullMethodName         = "/dev.sigstore.fulcio.v2.CA/GetConfiguration"
+	CA_CreateSigningCertificate_FullMethodName  = "/dev.sigstore.fulcio.v2.CA/CreateSigningCertificate"
+	CA_CreateSigningCertificates_FullMethodName = "/dev.sigstore.fulcio.v2.CA/CreateSigningCertificates"
+	CA_PreviewSigningCertificate_FullMethodName = "/dev.sigstore.fulcio.v2.CA/PreviewSigningCertificate"
+	CA_GetChallenge_FullMethodName              = "/dev.sigstore.fulcio.v2.CA/GetChallenge"
+	CA_ExplainIdentityToken_FullMethodName      = "/dev.sigstore.fulcio.v2.CA/ExplainIdentityToken"
+	CA_GetTrustBundle_FullMethodName            = "/dev.sigstore.fulcio.v2.CA/GetTrustBundle"
+	CA_GetTrustedRoot_FullMethodName            = "/dev.sigstore.fulcio.v2.CA/GetTrustedRoot"
+	CA_GetConfiguration_FullMethodName          = "/dev.sigstore.fulcio.v2.CA/GetConfiguration"
+	CA_GetCertificate_FullMethodName            = "/dev.sigstore.fulcio.v2.CA/GetCertificate"
+	CA_SearchCertificates_FullMethodName        = "/dev.sigstore.fulcio.v2.CA/SearchCertificates"
 )
 
 // CAClient is the client API for CA service.
@@ -47,11 +55,42 @@ type CAClient interface {
 	// Returns an X.509 certificate created by the Fulcio certificate authority for the given request parameters
 	CreateSigningCertificate(ctx context.Context, in *CreateSigningCertificateRequest, opts ...grpc.CallOption) (*SigningCertificate, error)
 	// *
+	// Returns an X.509 certificate for each of the given keys, all bound to the identity in a single set of credentials.
+	// Each key is processed independently, and failures are reported per key rather than failing the whole request.
+	CreateSigningCertificates(ctx context.Context, in *CreateSigningCertificatesRequest, opts ...grpc.CallOption) (*CreateSigningCertificatesResponse, error)
+	// *
+	// Returns the contents of the certificate that would be issued for the given request parameters, without
+	// signing it or submitting it to the CT log. Intended for validating issuer configurations with real tokens.
+	PreviewSigningCertificate(ctx context.Context, in *CreateSigningCertificateRequest, opts ...grpc.CallOption) (*CertificatePreview, error)
+	// *
+	// Returns a short-lived nonce bound to the identity in the given credentials. Signing the nonce instead of
+	// the identity as proof of possession prevents a captured proof from being reused with a stolen token.
+	GetChallenge(ctx context.Context, in *GetChallengeRequest, opts ...grpc.CallOption) (*Challenge, error)
+	// *
+	// Returns how the identity token in the given credentials is authenticated, reporting the step that
+	// failed if the token is rejected. Intended for diagnosing rejected tokens. The token and the policy of
+	// its issuer are checked; the key policy, challenges and authorization webhook depend on the key in a
+	// certificate request, so a token reported as accepted may still be rejected by them.
+	ExplainIdentityToken(ctx context.Context, in *ExplainIdentityTokenRequest, opts ...grpc.CallOption) (*IdentityTokenExplanation, error)
+	// *
 	// Returns the bundle of certificates that can be used to validate code signing certificates issued by this Fulcio instance
 	GetTrustBundle(ctx context.Context, in *GetTrustBundleRequest, opts ...grpc.CallOption) (*TrustBundle, error)
 	// *
+	// Returns a Sigstore TrustedRoot JSON document with the certificate chains of this Fulcio instance
+	// and, if its public key is configured, the CT log that certificates are submitted to.
+	GetTrustedRoot(ctx context.Context, in *GetTrustedRootRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error)
+	// *
 	// Returns the configuration of supported OIDC issuers, including the required challenge for each issuer.
 	GetConfiguration(ctx context.Context, in *GetConfigurationRequest, opts ...grpc.CallOption) (*Configuration, error)
+	// *
+	// Returns a certificate previously issued by this Fulcio instance. Only available if the instance
+	// is configured to store issued certificates.
+	GetCertificate(ctx context.Context, in *GetCertificateRequest, opts ...grpc.CallOption) (*IssuedCertificate, error)
+	// *
+	// Returns the certificates previously issued by this Fulcio instance that match the given identity,
+	// OIDC issuer and issuance time range, in order of issuance. Only available if the instance is
+	// configured to store issued certificates.
+	SearchCertificates(ctx context.Context, in *SearchCertificatesRequest, opts ...grpc.CallOption) (*SearchCertificatesResponse, error)
 }
 
 type cAClient struct {
@@ -72,6 +111,46 @@ func (c *cAClient) CreateSigningCertificate(ctx context.Context, in *CreateSigni
 	return out, nil
 }
 
+func (c *cAClient) CreateSigningCertificates(ctx context.Context, in *CreateSigningCertificatesRequest, opts ...grpc.CallOption) (*CreateSigningCertificatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateSigningCertificatesResponse)
+	err := c.cc.Invoke(ctx, CA_CreateSigningCertificates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cAClient) PreviewSigningCertificate(ctx context.Context, in *CreateSigningCertificateRequest, opts ...grpc.CallOption) (*CertificatePreview, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CertificatePreview)
+	err := c.cc.Invoke(ctx, CA_PreviewSigningCertificate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cAClient) GetChallenge(ctx context.Context, in *GetChallengeRequest, opts ...grpc.CallOption) (*Challenge, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Challenge)
+	err := c.cc.Invoke(ctx, CA_GetChallenge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cAClient) ExplainIdentityToken(ctx context.Context, in *ExplainIdentityTokenRequest, opts ...grpc.CallOption) (*IdentityTokenExplanation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IdentityTokenExplanation)
+	err := c.cc.Invoke(ctx, CA_ExplainIdentityToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *cAClient) GetTrustBundle(ctx context.Context, in *GetTrustBundleRequest, opts ...grpc.CallOption) (*TrustBundle, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(TrustBundle)
@@ -82,6 +161,16 @@ func (c *cAClient) GetTrustBundle(ctx context.Context, in *GetTrustBundleRequest
 	return out, nil
 }
 
+func (c *cAClient) GetTrustedRoot(ctx context.Context, in *GetTrustedRootRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(httpbody.HttpBody)
+	err := c.cc.Invoke(ctx, CA_GetTrustedRoot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *cAClient) GetConfiguration(ctx context.Context, in *GetConfigurationRequest, opts ...grpc.CallOption) (*Configuration, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Configuration)
@@ -92,6 +181,26 @@ func (c *cAClient) GetConfiguration(ctx context.Context, in *GetConfigurationReq
 	return out, nil
 }
 
+func (c *cAClient) GetCertificate(ctx context.Context, in *GetCertificateRequest, opts ...grpc.CallOption) (*IssuedCertificate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IssuedCertificate)
+	err := c.cc.Invoke(ctx, CA_GetCertificate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cAClient) SearchCertificates(ctx context.Context, in *SearchCertificatesRequest, opts ...grpc.CallOption) (*SearchCertificatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchCertificatesResponse)
+	err := c.cc.Invoke(ctx, CA_SearchCertificates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CAServer is the server API for CA service.
 // All implementations must embed UnimplementedCAServer
 // for forward compatibility.
@@ -100,11 +209,42 @@ type CAServer interface {
 	// Returns an X.509 certificate created by the Fulcio certificate authority for the given request parameters
 	CreateSigningCertificate(context.Context, *CreateSigningCertificateRequest) (*SigningCertificate, error)
 	// *
+	// Returns an X.509 certificate for each of the given keys, all bound to the identity in a single set of credentials.
+	// Each key is processed independently, and failures are reported per key rather than failing the whole request.
+	CreateSigningCertificates(context.Context, *CreateSigningCertificatesRequest) (*CreateSigningCertificatesResponse, error)
+	// *
+	// Returns the contents of the certificate that would be issued for the given request parameters, without
+	// signing it or submitting it to the CT log. Intended for validating issuer configurations with real tokens.
+	PreviewSigningCertificate(context.Context, *CreateSigningCertificateRequest) (*CertificatePreview, error)
+	// *
+	// Returns a short-lived nonce bound to the identity in the given credentials. Signing the nonce instead of
+	// the identity as proof of possession prevents a captured proof from being reused with a stolen token.
+	GetChallenge(context.Context, *GetChallengeRequest) (*Challenge, error)
+	// *
+	// Returns how the identity token in the given credentials is authenticated, reporting the step that
+	// failed if the token is rejected. Intended for diagnosing rejected tokens. The token and the policy of
+	// its issuer are checked; the key policy, challenges and authorization webhook depend on the key in a
+	// certificate request, so a token reported as accepted may still be rejected by them.
+	ExplainIdentityToken(context.Context, *ExplainIdentityTokenRequest) (*IdentityTokenExplanation, error)
+	// *
 	// Returns the bundle of certificates that can be used to validate code signing certificates issued by this Fulcio instance
 	GetTrustBundle(context.Context, *GetTrustBundleRequest) (*TrustBundle, error)
 	// *
+	// Returns a Sigstore TrustedRoot JSON document with the certificate chains of this Fulcio instance
+	// and, if its public key is configured, the CT log that certificates are submitted to.
+	GetTrustedRoot(context.Context, *GetTrustedRootRequest) (*httpbody.HttpBody, error)
+	// *
 	// Returns the configuration of supported OIDC issuers, including the required challenge for each issuer.
 	GetConfiguration(context.Context, *GetConfigurationRequest) (*Configuration, error)
+	// *
+	// Returns a certificate previously issued by this Fulcio instance. Only available if the instance
+	// is configured to store issued certificates.
+	GetCertificate(context.Context, *GetCertificateRequest) (*IssuedCertificate, error)
+	// *
+	// Returns the certificates previously issued by this Fulcio instance that match the given identity,
+	// OIDC issuer and issuance time range, in order of issuance. Only available if the instance is
+	// configured to store issued certificates.
+	SearchCertificates(context.Context, *SearchCertificatesRequest) (*SearchCertificatesResponse, error)
 	mustEmbedUnimplementedCAServer()
 }
 
@@ -118,12 +258,33 @@ type UnimplementedCAServer struct{}
 func (UnimplementedCAServer) CreateSigningCertificate(context.Context, *CreateSigningCertificateRequest) (*SigningCertificate, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateSigningCertificate not implemented")
 }
+func (UnimplementedCAServer) CreateSigningCertificates(context.Context, *CreateSigningCertificatesRequest) (*CreateSigningCertificatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSigningCertificates not implemented")
+}
+func (UnimplementedCAServer) PreviewSigningCertificate(context.Context, *CreateSigningCertificateRequest) (*CertificatePreview, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewSigningCertificate not implemented")
+}
+func (UnimplementedCAServer) GetChallenge(context.Context, *GetChallengeRequest) (*Challenge, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChallenge not implemented")
+}
+func (UnimplementedCAServer) ExplainIdentityToken(context.Context, *ExplainIdentityTokenRequest) (*IdentityTokenExplanation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExplainIdentityToken not implemented")
+}
 func (UnimplementedCAServer) GetTrustBundle(context.Context, *GetTrustBundleRequest) (*TrustBundle, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTrustBundle not implemented")
 }
+func (UnimplementedCAServer) GetTrustedRoot(context.Context, *GetTrustedRootRequest) (*httpbody.HttpBody, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrustedRoot not implemented")
+}
 func (UnimplementedCAServer) GetConfiguration(context.Context, *GetConfigurationRequest) (*Configuration, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetConfiguration not implemented")
 }
+func (UnimplementedCAServer) GetCertificate(context.Context, *GetCertificateRequest) (*IssuedCertificate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCertificate not implemented")
+}
+func (UnimplementedCAServer) SearchCertificates(context.Context, *SearchCertificatesRequest) (*SearchCertificatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchCertificates not implemented")
+}
 func (UnimplementedCAServer) mustEmbedUnimplementedCAServer() {}
 func (UnimplementedCAServer) testEmbeddedByValue()            {}
 
@@ -163,6 +324,78 @@ func _CA_CreateSigningCertificate_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CA_CreateSigningCertificates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSigningCertificatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CAServer).CreateSigningCertificates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CA_CreateSigningCertificates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CAServer).CreateSigningCertificates(ctx, req.(*CreateSigningCertificatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CA_PreviewSigningCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSigningCertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CAServer).PreviewSigningCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CA_PreviewSigningCertificate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CAServer).PreviewSigningCertificate(ctx, req.(*CreateSigningCertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CA_GetChallenge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChallengeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CAServer).GetChallenge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CA_GetChallenge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CAServer).GetChallenge(ctx, req.(*GetChallengeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CA_ExplainIdentityToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainIdentityTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CAServer).ExplainIdentityToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CA_ExplainIdentityToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CAServer).ExplainIdentityToken(ctx, req.(*ExplainIdentityTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CA_GetTrustBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetTrustBundleRequest)
 	if err := dec(in); err != nil {
@@ -181,6 +414,24 @@ func _CA_GetTrustBundle_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CA_GetTrustedRoot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTrustedRootRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CAServer).GetTrustedRoot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CA_GetTrustedRoot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CAServer).GetTrustedRoot(ctx, req.(*GetTrustedRootRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CA_GetConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetConfigurationRequest)
 	if err := dec(in); err != nil {
@@ -199,6 +450,42 @@ func _CA_GetConfiguration_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CA_GetCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CAServer).GetCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CA_GetCertificate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CAServer).GetCertificate(ctx, req.(*GetCertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CA_SearchCertificates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchCertificatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CAServer).SearchCertificates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CA_SearchCertificates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CAServer).SearchCertificates(ctx, req.(*SearchCertificatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CA_ServiceDesc is the grpc.ServiceDesc for CA service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -210,14 +497,42 @@ var CA_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateSigningCertificate",
 			Handler:    _CA_CreateSigningCertificate_Handler,
 		},
+		{
+			MethodName: "CreateSigningCertificates",
+			Handler:    _CA_CreateSigningCertificates_Handler,
+		},
+		{
+			MethodName: "PreviewSigningCertificate",
+			Handler:    _CA_PreviewSigningCertificate_Handler,
+		},
+		{
+			MethodName: "GetChallenge",
+			Handler:    _CA_GetChallenge_Handler,
+		},
+		{
+			MethodName: "ExplainIdentityToken",
+			Handler:    _CA_ExplainIdentityToken_Handler,
+		},
 		{
 			MethodName: "GetTrustBundle",
 			Handler:    _CA_GetTrustBundle_Handler,
 		},
+		{
+			MethodName: "GetTrustedRoot",
+			Handler:    _CA_GetTrustedRoot_Handler,
+		},
 		{
 			MethodName: "GetConfiguration",
 			Handler:    _CA_GetConfiguration_Handler,
 		},
+		{
+			MethodName: "GetCertificate",
+			Handler:    _CA_GetCertificate_Handler,
+		},
+		{
+			MethodName: "SearchCertificates",
+			Handler:    _CA_SearchCertificates_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "fulcio.proto",
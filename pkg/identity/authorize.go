@@ -16,24 +16,72 @@ package identity
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/sigstore/fulcio/pkg/config"
 )
 
+// ErrTokenVerification wraps errors verifying the signature, audience or
+// expiry of a token.
+var ErrTokenVerification = errors.New("verifying token")
+
+// ErrAudienceMismatch is wrapped with ErrTokenVerification when a token was
+// not issued for the client ID configured for its issuer.
+var ErrAudienceMismatch = errors.New("token audience does not match client ID")
+
 // We do this to bypass needing actual OIDC tokens for unit testing.
 var Authorize = actualAuthorize
 
 func actualAuthorize(ctx context.Context, token string, opts ...config.InsecureOIDCConfigOption) (*oidc.IDToken, error) {
-	issuer, err := extractIssuerURL(token)
+	issuer, err := ExtractIssuerURL(token)
 	if err != nil {
 		return nil, err
 	}
 
 	verifier, ok := config.FromContext(ctx).GetVerifier(issuer, opts...)
 	if !ok {
-		return nil, fmt.Errorf("unsupported issuer: %s", issuer)
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedIssuer, issuer)
+	}
+	idToken, err := verifier.Verify(ctx, token)
+	if err != nil {
+		if !audienceMatches(ctx, issuer, token, opts...) {
+			return nil, fmt.Errorf("%w: %w: %w", ErrTokenVerification, ErrAudienceMismatch, err)
+		}
+		return nil, fmt.Errorf("%w: %w", ErrTokenVerification, err)
+	}
+	return idToken, nil
+}
+
+// audienceMatches reports whether the unverified audience of a token includes
+// the client ID configured for its issuer, so that a failed verification can
+// be attributed to the audience.
+func audienceMatches(ctx context.Context, issuerURL, token string, opts ...config.InsecureOIDCConfigOption) bool {
+	iss, ok := config.FromContext(ctx).GetIssuer(issuerURL)
+	if !ok {
+		return true
+	}
+	cfg := &oidc.Config{ClientID: iss.ClientID}
+	for _, o := range opts {
+		o(cfg)
+	}
+	if cfg.SkipClientIDCheck {
+		return true
+	}
+	claims, err := ExtractClaims(token)
+	if err != nil {
+		return true
+	}
+	switch aud := claims["aud"].(type) {
+	case string:
+		return aud == cfg.ClientID
+	case []any:
+		for _, a := range aud {
+			if a == cfg.ClientID {
+				return true
+			}
+		}
 	}
-	return verifier.Verify(ctx, token)
+	return false
 }
@@ -0,0 +1,83 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package identity
+
+import (
+	"context"
+	"fmt"
+)
+
+// Explanation describes each step of authenticating a token, for diagnosing
+// rejected tokens. The steps run in order and at most one error is set, for
+// the step that failed.
+type Explanation struct {
+	// Issuer is the unverified issuer claim of the token
+	Issuer string
+	// ParseError is set if the token is not a well-formed JWT
+	ParseError error
+	// MatchError is set if no issuer in the pool matches the issuer claim
+	MatchError error
+	// VerificationError is set if the signature, audience or expiry of the
+	// token are invalid
+	VerificationError error
+	// ClaimsError is set if the claims of the token can't be used by the
+	// matched issuer, such as when a required claim is missing
+	ClaimsError error
+	// Principal is set if the token is accepted
+	Principal Principal
+}
+
+// Explain authenticates token like Authenticate, recording which step failed.
+// Tokens are not recorded as used by issuers that prevent replay.
+func (p IssuerPool) Explain(ctx context.Context, token string) Explanation {
+	var e Explanation
+	e.Issuer, e.ParseError = ExtractIssuerURL(token)
+	if e.ParseError != nil {
+		return e
+	}
+
+	var matched Issuer
+	for _, issuer := range p {
+		if issuer.Match(ctx, e.Issuer) {
+			matched = issuer
+			break
+		}
+	}
+	if matched == nil {
+		e.MatchError = fmt.Errorf("%w: failed to match issuer URL %s from token with any configured providers", ErrUnsupportedIssuer, e.Issuer)
+		return e
+	}
+	matched = unguarded(matched)
+
+	// Every issuer authorizes the token before reading its claims, so
+	// authorizing here separates verification failures from claim failures
+	if _, e.VerificationError = Authorize(ctx, token); e.VerificationError != nil {
+		return e
+	}
+	e.Principal, e.ClaimsError = matched.Authenticate(ctx, token)
+	return e
+}
+
+// Err returns the error of the step that failed, or nil if the token is
+// accepted.
+func (e Explanation) Err() error {
+	for _, err := range []error{e.ParseError, e.MatchError, e.VerificationError, e.ClaimsError} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
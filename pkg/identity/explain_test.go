@@ -0,0 +1,56 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identity
+
+import (
+	"context"
+	"testing"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/sigstore/fulcio/pkg/config"
+)
+
+func TestExplainDoesNotRecordToken(t *testing.T) {
+	acceptAll := testIssuer{
+		match: func(context.Context, string) bool { return true },
+		auth: func(context.Context, string) (Principal, error) {
+			return testPrincipal{`alice`}, nil
+		},
+	}
+	ctx := config.With(context.Background(), &config.FulcioConfig{
+		OIDCIssuers: map[string]config.OIDCIssuer{
+			"single.com": {IssuerURL: "single.com", SingleUseTokens: true},
+		},
+	})
+	defer func(authorize func(context.Context, string, ...config.InsecureOIDCConfigOption) (*oidc.IDToken, error)) {
+		Authorize = authorize
+	}(Authorize)
+	Authorize = func(context.Context, string, ...config.InsecureOIDCConfigOption) (*oidc.IDToken, error) {
+		return &oidc.IDToken{}, nil
+	}
+
+	store, err := NewMemoryReplayStore(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := PreventReplay(IssuerPool{acceptAll}, store)
+	token := unsignedToken(t, map[string]interface{}{"iss": "single.com", "jti": "1"})
+	if e := pool.Explain(ctx, token); e.Principal == nil {
+		t.Fatalf("Explain() = %+v, wanted principal", e)
+	}
+	if _, err := pool.Authenticate(ctx, token); err != nil {
+		t.Fatalf("Authenticate() after Explain() = %v", err)
+	}
+}
@@ -18,16 +18,25 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/sigstore/fulcio/pkg/config"
 )
 
+var (
+	// ErrMalformedToken is returned for tokens that are not well-formed JWTs.
+	ErrMalformedToken = errors.New("oidc: malformed jwt")
+	// ErrUnsupportedIssuer is returned for tokens from an issuer that is not
+	// configured.
+	ErrUnsupportedIssuer = errors.New("unsupported issuer")
+)
+
 type IssuerPool []Issuer
 
 func (p IssuerPool) Authenticate(ctx context.Context, token string, opts ...config.InsecureOIDCConfigOption) (Principal, error) {
-	url, err := extractIssuerURL(token)
+	url, err := ExtractIssuerURL(token)
 	if err != nil {
 		return nil, err
 	}
@@ -37,18 +46,59 @@ func (p IssuerPool) Authenticate(ctx context.Context, token string, opts ...conf
 			return issuer.Authenticate(ctx, token, opts...)
 		}
 	}
-	return nil, fmt.Errorf("failed to match issuer URL %s from token with any configured providers", url)
+	return nil, fmt.Errorf("%w: failed to match issuer URL %s from token with any configured providers", ErrUnsupportedIssuer, url)
 }
 
-func extractIssuerURL(token string) (string, error) {
-	parts := strings.Split(token, ".")
-	if len(parts) != 3 {
-		return "", fmt.Errorf("oidc: malformed jwt, expected 3 parts got %d", len(parts))
+// Verify authenticates token like Authenticate, but doesn't record it as used
+// for issuers that prevent replay, so that the token can be checked before a
+// request is known to proceed. Consume must be called if it does.
+func (p IssuerPool) Verify(ctx context.Context, token string, opts ...config.InsecureOIDCConfigOption) (Principal, error) {
+	url, err := ExtractIssuerURL(token)
+	if err != nil {
+		return nil, err
 	}
 
-	raw, err := base64.RawURLEncoding.DecodeString(parts[1])
+	for _, issuer := range p {
+		if issuer.Match(ctx, url) {
+			return unguarded(issuer).Authenticate(ctx, token, opts...)
+		}
+	}
+	return nil, fmt.Errorf("%w: failed to match issuer URL %s from token with any configured providers", ErrUnsupportedIssuer, url)
+}
+
+// Consume records a token that was checked with Verify as used, and returns
+// ErrTokenReplayed if it was already used, for issuers that prevent replay.
+// It does nothing for other issuers.
+func (p IssuerPool) Consume(ctx context.Context, token string) error {
+	url, err := ExtractIssuerURL(token)
 	if err != nil {
-		return "", fmt.Errorf("oidc: malformed jwt payload: %w", err)
+		return err
+	}
+
+	for _, issuer := range p {
+		if issuer.Match(ctx, url) {
+			if r, ok := issuer.(replayGuard); ok {
+				return r.consume(ctx, token)
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// unguarded returns the issuer wrapped by PreventReplay, if any.
+func unguarded(issuer Issuer) Issuer {
+	if r, ok := issuer.(replayGuard); ok {
+		return r.Issuer
+	}
+	return issuer
+}
+
+// ExtractIssuerURL returns the issuer claim of a token without verifying it.
+func ExtractIssuerURL(token string) (string, error) {
+	raw, err := decodePayload(token)
+	if err != nil {
+		return "", err
 	}
 
 	var payload struct {
@@ -59,3 +109,32 @@ func extractIssuerURL(token string) (string, error) {
 	}
 	return payload.Issuer, nil
 }
+
+// ExtractClaims returns the claims of a token without verifying it, so it
+// must only be used on tokens that have already been authenticated.
+func ExtractClaims(token string) (map[string]any, error) {
+	raw, err := decodePayload(token)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := map[string]any{}
+	if err := json.Unmarshal(raw, &claims); err != nil {
+		return nil, fmt.Errorf("oidc: failed to unmarshal claims: %w", err)
+	}
+	return claims, nil
+}
+
+// decodePayload returns the unverified JSON payload of a token.
+func decodePayload(token string) ([]byte, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w, expected 3 parts got %d", ErrMalformedToken, len(parts))
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w payload: %w", ErrMalformedToken, err)
+	}
+	return raw, nil
+}
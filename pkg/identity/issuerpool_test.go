@@ -200,7 +200,7 @@ func TestExtractIssuerURL(t *testing.T) {
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			gotURL, err := extractIssuerURL(test.Token)
+			gotURL, err := ExtractIssuerURL(test.Token)
 			if err != nil {
 				if !test.WantErr {
 					t.Error(err)
@@ -0,0 +1,150 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package identity
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/sigstore/fulcio/pkg/config"
+)
+
+// ErrTokenReplayed is returned when a single-use token is presented again.
+var ErrTokenReplayed = errors.New("token has already been used")
+
+// maxReplayWindow is how long tokens without an expiry are remembered.
+const maxReplayWindow = 24 * time.Hour
+
+// ReplayStore records the tokens that have been used. Deployments with
+// multiple replicas should use a store shared between them, otherwise a token
+// may be used once per replica.
+type ReplayStore interface {
+	// Add records key until expiry. It returns false if key was already
+	// recorded and has not yet expired.
+	Add(ctx context.Context, key string, expiry time.Time) (bool, error)
+}
+
+// MemoryReplayStore is a ReplayStore for a single instance. It holds a
+// bounded number of keys; when full, the least recently added key is
+// forgotten, so the size should exceed the number of tokens accepted within
+// their lifetime.
+type MemoryReplayStore struct {
+	mu    sync.Mutex
+	cache *lru.Cache
+}
+
+// NewMemoryReplayStore returns a store holding at most size keys.
+func NewMemoryReplayStore(size int) (*MemoryReplayStore, error) {
+	cache, err := lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+	return &MemoryReplayStore{cache: cache}, nil
+}
+
+func (s *MemoryReplayStore) Add(_ context.Context, key string, expiry time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if prev, ok := s.cache.Get(key); ok && time.Now().Before(prev.(time.Time)) {
+		return false, nil
+	}
+	s.cache.Add(key, expiry)
+	return true, nil
+}
+
+// PreventReplay returns a pool that rejects a token with ErrTokenReplayed if
+// it was already accepted, for issuers configured with SingleUseTokens.
+func PreventReplay(ip IssuerPool, store ReplayStore) IssuerPool {
+	guarded := make(IssuerPool, 0, len(ip))
+	for _, iss := range ip {
+		guarded = append(guarded, replayGuard{Issuer: iss, store: store})
+	}
+	return guarded
+}
+
+type replayGuard struct {
+	Issuer
+	store ReplayStore
+}
+
+func (r replayGuard) Authenticate(ctx context.Context, token string, opts ...config.InsecureOIDCConfigOption) (Principal, error) {
+	principal, err := r.Issuer.Authenticate(ctx, token, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.consume(ctx, token); err != nil {
+		return nil, err
+	}
+	return principal, nil
+}
+
+// consume records a verified token as used, and returns ErrTokenReplayed if
+// it was already used, if the issuer of the token is configured with
+// SingleUseTokens.
+func (r replayGuard) consume(ctx context.Context, token string) error {
+	// The token has been verified, so its claims can be trusted
+	raw, err := decodePayload(token)
+	if err != nil {
+		return err
+	}
+	var claims struct {
+		Issuer string          `json:"iss"`
+		ID     json.RawMessage `json:"jti"`
+		Expiry float64         `json:"exp"`
+	}
+	if err := json.Unmarshal(raw, &claims); err != nil {
+		return fmt.Errorf("oidc: failed to unmarshal claims: %w", err)
+	}
+
+	cfg := config.FromContext(ctx)
+	if cfg == nil {
+		return nil
+	}
+	if iss, ok := cfg.GetIssuer(claims.Issuer); !ok || !iss.SingleUseTokens {
+		return nil
+	}
+
+	var key string
+	if jti := ""; json.Unmarshal(claims.ID, &jti) == nil && jti != "" {
+		key = "jti:" + claims.Issuer + "\x00" + jti
+	} else {
+		digest := sha256.Sum256([]byte(token))
+		key = "sha256:" + hex.EncodeToString(digest[:])
+	}
+	expiry := time.Now().Add(maxReplayWindow)
+	if claims.Expiry > 0 {
+		sec, frac := math.Modf(claims.Expiry)
+		expiry = time.Unix(int64(sec), int64(frac*1e9))
+	}
+
+	fresh, err := r.store.Add(ctx, key, expiry)
+	if err != nil {
+		return fmt.Errorf("checking for token replay: %w", err)
+	}
+	if !fresh {
+		return ErrTokenReplayed
+	}
+	return nil
+}
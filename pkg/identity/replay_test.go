@@ -0,0 +1,171 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identity
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sigstore/fulcio/pkg/config"
+)
+
+func unsignedToken(t *testing.T, claims map[string]interface{}) string {
+	t.Helper()
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(`{"alg":"none"}`)) + "." + enc.EncodeToString(payload) + ".sig"
+}
+
+func TestPreventReplay(t *testing.T) {
+	acceptAll := testIssuer{
+		match: func(context.Context, string) bool { return true },
+		auth: func(context.Context, string) (Principal, error) {
+			return testPrincipal{`alice`}, nil
+		},
+	}
+	cfg := &config.FulcioConfig{
+		OIDCIssuers: map[string]config.OIDCIssuer{
+			"single.com":   {IssuerURL: "single.com", SingleUseTokens: true},
+			"multiple.com": {IssuerURL: "multiple.com"},
+		},
+	}
+	ctx := config.With(context.Background(), cfg)
+	exp := time.Now().Add(time.Hour).Unix()
+
+	tests := map[string]struct {
+		Token      string
+		WantReplay bool
+	}{
+		`single-use token is rejected`: {
+			Token:      unsignedToken(t, map[string]interface{}{"iss": "single.com", "jti": "1", "exp": exp}),
+			WantReplay: true,
+		},
+		`single-use token without jti is rejected`: {
+			Token:      unsignedToken(t, map[string]interface{}{"iss": "single.com", "exp": exp}),
+			WantReplay: true,
+		},
+		`single-use token with non-string jti is rejected`: {
+			Token:      unsignedToken(t, map[string]interface{}{"iss": "single.com", "jti": 2}),
+			WantReplay: true,
+		},
+		`expired entry is not a replay`: {
+			Token:      unsignedToken(t, map[string]interface{}{"iss": "single.com", "jti": "3", "exp": time.Now().Add(-time.Minute).Unix()}),
+			WantReplay: false,
+		},
+		`reusable token is accepted`: {
+			Token:      unsignedToken(t, map[string]interface{}{"iss": "multiple.com", "jti": "1", "exp": exp}),
+			WantReplay: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			store, err := NewMemoryReplayStore(10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pool := PreventReplay(IssuerPool{acceptAll}, store)
+			if _, err := pool.Authenticate(ctx, test.Token); err != nil {
+				t.Fatalf("first use: Authenticate() = %v", err)
+			}
+			_, err = pool.Authenticate(ctx, test.Token)
+			if gotReplay := errors.Is(err, ErrTokenReplayed); gotReplay != test.WantReplay {
+				t.Fatalf("second use: Authenticate() = %v, wanted replay %v", err, test.WantReplay)
+			}
+		})
+	}
+
+	// Tokens with the same jti from different issuers are distinct
+	cfg.OIDCIssuers["multiple.com"] = config.OIDCIssuer{IssuerURL: "multiple.com", SingleUseTokens: true}
+	store, err := NewMemoryReplayStore(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := PreventReplay(IssuerPool{acceptAll}, store)
+	for _, iss := range []string{"single.com", "multiple.com"} {
+		if _, err := pool.Authenticate(ctx, unsignedToken(t, map[string]interface{}{"iss": iss, "jti": "1", "exp": exp})); err != nil {
+			t.Fatalf("Authenticate(%s) = %v", iss, err)
+		}
+	}
+}
+
+func TestPreventReplayRejectedToken(t *testing.T) {
+	rejectAll := testIssuer{
+		match: func(context.Context, string) bool { return true },
+		auth: func(context.Context, string) (Principal, error) {
+			return nil, errors.New(`boooooo`)
+		},
+	}
+	store, err := NewMemoryReplayStore(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := config.With(context.Background(), &config.FulcioConfig{
+		OIDCIssuers: map[string]config.OIDCIssuer{
+			"single.com": {IssuerURL: "single.com", SingleUseTokens: true},
+		},
+	})
+	pool := PreventReplay(IssuerPool{rejectAll}, store)
+	token := unsignedToken(t, map[string]interface{}{"iss": "single.com", "jti": "1"})
+	if _, err := pool.Authenticate(ctx, token); err == nil || errors.Is(err, ErrTokenReplayed) {
+		t.Fatalf("Authenticate() = %v, wanted authentication error", err)
+	}
+	// A rejected token is not recorded
+	if ok, _ := store.Add(ctx, "jti:single.com\x001", time.Now().Add(time.Hour)); !ok {
+		t.Fatal("rejected token was recorded")
+	}
+}
+
+func TestVerifyAndConsume(t *testing.T) {
+	acceptAll := testIssuer{
+		match: func(context.Context, string) bool { return true },
+		auth: func(context.Context, string) (Principal, error) {
+			return testPrincipal{`alice`}, nil
+		},
+	}
+	store, err := NewMemoryReplayStore(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := config.With(context.Background(), &config.FulcioConfig{
+		OIDCIssuers: map[string]config.OIDCIssuer{
+			"single.com": {IssuerURL: "single.com", SingleUseTokens: true},
+		},
+	})
+	pool := PreventReplay(IssuerPool{acceptAll}, store)
+	token := unsignedToken(t, map[string]interface{}{"iss": "single.com", "jti": "1", "exp": time.Now().Add(time.Hour).Unix()})
+
+	// Verifying a token doesn't use it up
+	for i := 0; i < 2; i++ {
+		if _, err := pool.Verify(ctx, token); err != nil {
+			t.Fatalf("Verify() = %v", err)
+		}
+	}
+	if err := pool.Consume(ctx, token); err != nil {
+		t.Fatalf("Consume() = %v", err)
+	}
+	if err := pool.Consume(ctx, token); !errors.Is(err, ErrTokenReplayed) {
+		t.Fatalf("Consume() = %v, wanted %v", err, ErrTokenReplayed)
+	}
+	if _, err := pool.Authenticate(ctx, token); !errors.Is(err, ErrTokenReplayed) {
+		t.Fatalf("Authenticate() = %v, wanted %v", err, ErrTokenReplayed)
+	}
+}
@@ -53,6 +53,25 @@ func PrincipalFromIDToken(ctx context.Context, token *oidc.IDToken) (identity.Pr
 
 }
 
+// PrincipalFromX509SVID returns the principal of an X.509-SVID that has
+// already been verified against the CAs of the trust domain. issuer is
+// recorded as the issuer of certificates issued to the principal.
+func PrincipalFromX509SVID(cert *x509.Certificate, trustDomain, issuer string) (identity.Principal, error) {
+	// An X.509-SVID has exactly one URI SAN, its SPIFFE ID
+	if len(cert.URIs) != 1 {
+		return nil, fmt.Errorf("X.509-SVID must have exactly one URI SAN, found %d", len(cert.URIs))
+	}
+	id := cert.URIs[0].String()
+	if err := validSpiffeID(id, trustDomain); err != nil {
+		return nil, err
+	}
+
+	return principal{
+		id:     id,
+		issuer: issuer,
+	}, nil
+}
+
 func validSpiffeID(id, trustDomain string) error {
 	parsedTrustDomain, err := spiffeid.TrustDomainFromString(trustDomain)
 	if err != nil {
@@ -93,6 +93,62 @@ func TestPrincipalFromIDToken(t *testing.T) {
 	}
 }
 
+func TestPrincipalFromX509SVID(t *testing.T) {
+	mustParse := func(raw string) *url.URL {
+		u, err := url.Parse(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return u
+	}
+	tests := map[string]struct {
+		URIs      []*url.URL
+		Principal principal
+		WantErr   bool
+	}{
+		`Valid SVID authenticates with its SPIFFE ID`: {
+			URIs: []*url.URL{mustParse("spiffe://example.com/foo/bar")},
+			Principal: principal{
+				issuer: "https://spire.example.com",
+				id:     "spiffe://example.com/foo/bar",
+			},
+		},
+		`Incorrect trust domain should error`: {
+			URIs:    []*url.URL{mustParse("spiffe://foo.example.com/foo/bar")},
+			WantErr: true,
+		},
+		`Missing URI SAN should error`: {
+			WantErr: true,
+		},
+		`Multiple URI SANs should error`: {
+			URIs:    []*url.URL{mustParse("spiffe://example.com/foo"), mustParse("spiffe://example.com/bar")},
+			WantErr: true,
+		},
+		`Invalid ID should error`: {
+			URIs:    []*url.URL{mustParse("https://example.com/foo")},
+			WantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			untyped, err := PrincipalFromX509SVID(&x509.Certificate{URIs: test.URIs}, "example.com", "https://spire.example.com")
+			if err != nil {
+				if !test.WantErr {
+					t.Fatal("didn't expect error", err)
+				}
+				return
+			}
+			if test.WantErr {
+				t.Fatal("expected error but got none")
+			}
+			if p, ok := untyped.(principal); !ok || p != test.Principal {
+				t.Errorf("got %v principal and expected %v", untyped, test.Principal)
+			}
+		})
+	}
+}
+
 func TestName(t *testing.T) {
 	tests := map[string]struct {
 		Token        *oidc.IDToken
@@ -76,18 +76,28 @@ const (
 
 func ContextLogger(ctx context.Context) *zap.SugaredLogger {
 	proposedLogger := Logger
-	if ctx != nil {
-		if md, ok := metadata.FromIncomingContext(ctx); ok {
-			val := md.Get(string(requestIDMetadataKey))
-			if len(val) == 1 {
-				proposedLogger = proposedLogger.With(zap.String("requestID", val[0]))
-			}
-		}
+	if requestID := RequestID(ctx); requestID != "" {
+		proposedLogger = proposedLogger.With(zap.String("requestID", requestID))
 	}
 
 	return proposedLogger
 }
 
+// RequestID returns the ID of the request in the incoming metadata of the
+// context, or an empty string if there is none.
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		val := md.Get(string(requestIDMetadataKey))
+		if len(val) == 1 {
+			return val[0]
+		}
+	}
+	return ""
+}
+
 func SetupGRPCLogging() (*zap.Logger, []grpc_zap.Option) {
 	var options []grpc_zap.Option
 	options = append(options, grpc_zap.WithDecider(func(_ string, _ error) bool {
@@ -0,0 +1,88 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package server
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"google.golang.org/grpc/codes"
+
+	"github.com/sigstore/fulcio/pkg/authz"
+	certauth "github.com/sigstore/fulcio/pkg/ca"
+	fulciogrpc "github.com/sigstore/fulcio/pkg/generated/protobuf"
+	"github.com/sigstore/fulcio/pkg/identity"
+)
+
+// WithAuthorizationWebhook asks w whether each certificate may be issued,
+// after the request has been authenticated and before the CA is called.
+func WithAuthorizationWebhook(w *authz.Webhook) GRPCCAServerOption {
+	return func(g *grpcaCAServer) {
+		g.authorizer = w
+	}
+}
+
+// requestClaims returns the claims of the token in the credentials, or nil
+// for client certificate credentials.
+func requestClaims(ctx context.Context, credentials *fulciogrpc.Credentials) map[string]any {
+	if credentials.GetClientCertificate() != nil {
+		return nil
+	}
+	// The request has been authenticated, so the token can be parsed
+	claims, _ := identity.ExtractClaims(requestToken(ctx, credentials))
+	return claims
+}
+
+// authorize asks the authorization webhook, if one is configured, whether a
+// certificate may be issued to principal for publicKey.
+func (g *grpcaCAServer) authorize(ctx context.Context, principal identity.Principal, issuerURL string, claims map[string]any, publicKey crypto.PublicKey) error {
+	if g.authorizer == nil {
+		return nil
+	}
+	cert, err := certauth.MakeX509(ctx, principal, publicKey)
+	if err != nil {
+		// Errors embedding the principal are caused by the token's claims
+		if _, ok := err.(certauth.ValidationError); ok {
+			return handleFulcioGRPCError(ctx, codes.InvalidArgument, ReasonInvalidClaims, err, err.Error())
+		}
+		return handleFulcioGRPCError(ctx, codes.Internal, ReasonCAError, err, genericCAError)
+	}
+	der, err := cryptoutils.MarshalPublicKeyToDER(publicKey)
+	if err != nil {
+		return handleFulcioGRPCError(ctx, codes.InvalidArgument, ReasonInvalidPublicKey, err, invalidPublicKey)
+	}
+	digest := sha256.Sum256(der)
+
+	err = g.authorizer.Authorize(ctx, &authz.Request{
+		Issuer:                  issuerURL,
+		Principal:               principal.Name(ctx),
+		Claims:                  claims,
+		PublicKeyFingerprint:    hex.EncodeToString(digest[:]),
+		SubjectAlternativeNames: subjectAlternativeNames(cert),
+	})
+	var denied *authz.DeniedError
+	switch {
+	case errors.As(err, &denied):
+		return handleFulcioGRPCError(ctx, codes.PermissionDenied, ReasonAuthorizationDenied, err, authorizationDenied)
+	case err != nil:
+		return handleFulcioGRPCError(ctx, codes.Unavailable, ReasonAuthorizationUnavailable, err, authorizationUnavailable)
+	}
+	return nil
+}
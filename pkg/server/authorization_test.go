@@ -0,0 +1,295 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4/jwt"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sigstore/fulcio/pkg/authz"
+	"github.com/sigstore/fulcio/pkg/config"
+	"github.com/sigstore/fulcio/pkg/generated/protobuf"
+	"github.com/sigstore/fulcio/pkg/identity"
+)
+
+func TestAPIWithAuthorizationWebhook(t *testing.T) {
+	emailSigner, emailIssuer := newOIDCIssuer(t)
+
+	cfg, err := config.Read([]byte(fmt.Sprintf(`{
+		"OIDCIssuers": {
+			%q: {
+				"IssuerURL": %q,
+				"ClientID": "sigstore",
+				"Type": "email"
+			}
+		}
+	}`, emailIssuer, emailIssuer)))
+	if err != nil {
+		t.Fatalf("config.Read() = %v", err)
+	}
+
+	requests := make(chan authz.Request, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Input authz.Request `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		requests <- body.Input
+		if body.Input.Claims["email"] == "foo@example.com" {
+			fmt.Fprint(w, `{"result": {"allow": true}}`)
+			return
+		}
+		fmt.Fprint(w, `{"result": {"allow": false, "reason": "not on the allowlist"}}`)
+	}))
+	defer srv.Close()
+	webhook, err := authz.NewWebhook(srv.URL, &http.Client{Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("NewWebhook() = %v", err)
+	}
+
+	ctClient, eca := createCA(cfg, t)
+	g := NewGRPCCAServer(eca, NewIssuerPool(cfg), withCTLog(ctClient), WithAuthorizationWebhook(webhook))
+	ctx := config.With(context.Background(), cfg)
+
+	request := func(emailSubject string) (string, error) {
+		tok, err := jwt.Signed(emailSigner).Claims(jwt.Claims{
+			Issuer:   emailIssuer,
+			IssuedAt: jwt.NewNumericDate(time.Now()),
+			Expiry:   jwt.NewNumericDate(time.Now().Add(30 * time.Minute)),
+			Subject:  emailSubject,
+			Audience: jwt.Audience{"sigstore"},
+		}).Claims(customClaims{Email: emailSubject, EmailVerified: true}).Serialize()
+		if err != nil {
+			t.Fatalf("Serialize() = %v", err)
+		}
+		pubBytes, proof := generateKeyAndProof(emailSubject, t)
+		_, err = g.CreateSigningCertificate(ctx, &protobuf.CreateSigningCertificateRequest{
+			Credentials: &protobuf.Credentials{
+				Credentials: &protobuf.Credentials_OidcIdentityToken{
+					OidcIdentityToken: tok,
+				},
+			},
+			Key: &protobuf.CreateSigningCertificateRequest_PublicKeyRequest{
+				PublicKeyRequest: &protobuf.PublicKeyRequest{
+					PublicKey: &protobuf.PublicKey{
+						Content: pubBytes,
+					},
+					ProofOfPossession: proof,
+				},
+			},
+		})
+		return pubBytes, err
+	}
+
+	pubBytes, err := request("foo@example.com")
+	if err != nil {
+		t.Fatalf("CreateSigningCertificate() = %v", err)
+	}
+	input := <-requests
+	block, _ := pem.Decode([]byte(pubBytes))
+	digest := sha256.Sum256(block.Bytes)
+	if input.Issuer != emailIssuer || input.Principal != "foo@example.com" {
+		t.Errorf("unexpected identity in authorization request: %+v", input)
+	}
+	if input.PublicKeyFingerprint != hex.EncodeToString(digest[:]) {
+		t.Errorf("got fingerprint %s, wanted %s", input.PublicKeyFingerprint, hex.EncodeToString(digest[:]))
+	}
+	if !slices.Equal(input.SubjectAlternativeNames, []string{"foo@example.com"}) {
+		t.Errorf("got SANs %v, wanted [foo@example.com]", input.SubjectAlternativeNames)
+	}
+
+	_, err = request("bar@example.com")
+	<-requests
+	if status.Code(err) != codes.PermissionDenied || status.Convert(err).Message() != authorizationDenied {
+		t.Fatalf("expected request to be denied, got %v", err)
+	}
+	var info *errdetails.ErrorInfo
+	for _, detail := range statusDetails(t, err) {
+		if i, ok := detail.(*errdetails.ErrorInfo); ok {
+			info = i
+		}
+	}
+	if info == nil || info.Reason != ReasonAuthorizationDenied || info.Metadata["denialReason"] != "not on the allowlist" {
+		t.Errorf("unexpected error info %v", info)
+	}
+
+	// Requests fail if the webhook can't be reached
+	srv.Close()
+	if _, err := request("foo@example.com"); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected request to fail with the webhook unavailable, got %v", err)
+	}
+}
+
+// Tests that cached decisions are reused for fresh tokens and keys of the same
+// identity
+func TestAPIWithCachedAuthorizationWebhook(t *testing.T) {
+	emailSigner, emailIssuer := newOIDCIssuer(t)
+
+	cfg, err := config.Read([]byte(fmt.Sprintf(`{
+		"OIDCIssuers": {
+			%q: {
+				"IssuerURL": %q,
+				"ClientID": "sigstore",
+				"Type": "email"
+			}
+		}
+	}`, emailIssuer, emailIssuer)))
+	if err != nil {
+		t.Fatalf("config.Read() = %v", err)
+	}
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		fmt.Fprint(w, `{"result": {"allow": true}}`)
+	}))
+	defer srv.Close()
+	webhook, err := authz.NewWebhook(srv.URL, &http.Client{Timeout: 5 * time.Second}, authz.WithCache(time.Minute, authz.DefaultCacheSize))
+	if err != nil {
+		t.Fatalf("NewWebhook() = %v", err)
+	}
+
+	ctClient, eca := createCA(cfg, t)
+	g := NewGRPCCAServer(eca, NewIssuerPool(cfg), withCTLog(ctClient), WithAuthorizationWebhook(webhook))
+	ctx := config.With(context.Background(), cfg)
+
+	emailSubject := "foo@example.com"
+	for _, id := range []string{"token-1", "token-2"} {
+		tok, err := jwt.Signed(emailSigner).Claims(jwt.Claims{
+			Issuer:   emailIssuer,
+			IssuedAt: jwt.NewNumericDate(time.Now()),
+			Expiry:   jwt.NewNumericDate(time.Now().Add(30 * time.Minute)),
+			Subject:  emailSubject,
+			Audience: jwt.Audience{"sigstore"},
+			ID:       id,
+		}).Claims(customClaims{Email: emailSubject, EmailVerified: true}).Serialize()
+		if err != nil {
+			t.Fatalf("Serialize() = %v", err)
+		}
+		pubBytes, proof := generateKeyAndProof(emailSubject, t)
+		_, err = g.CreateSigningCertificate(ctx, &protobuf.CreateSigningCertificateRequest{
+			Credentials: &protobuf.Credentials{
+				Credentials: &protobuf.Credentials_OidcIdentityToken{
+					OidcIdentityToken: tok,
+				},
+			},
+			Key: &protobuf.CreateSigningCertificateRequest_PublicKeyRequest{
+				PublicKeyRequest: &protobuf.PublicKeyRequest{
+					PublicKey: &protobuf.PublicKey{
+						Content: pubBytes,
+					},
+					ProofOfPossession: proof,
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("CreateSigningCertificate() = %v", err)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("webhook called %d times, wanted 1", got)
+	}
+}
+
+// Tests that previews check issuer policies, but don't use up single-use
+// tokens or ask the authorization webhook
+func TestAPIPreviewWithoutSideEffects(t *testing.T) {
+	emailSigner, emailIssuer := newOIDCIssuer(t)
+
+	cfg, err := config.Read([]byte(fmt.Sprintf(`{
+		"OIDCIssuers": {
+			%q: {
+				"IssuerURL": %q,
+				"ClientID": "sigstore",
+				"Type": "email",
+				"SingleUseTokens": true,
+				"Policy": "principal.endsWith('@example.com')"
+			}
+		}
+	}`, emailIssuer, emailIssuer)))
+	if err != nil {
+		t.Fatalf("config.Read() = %v", err)
+	}
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		fmt.Fprint(w, `{"result": {"allow": true}}`)
+	}))
+	defer srv.Close()
+	webhook, err := authz.NewWebhook(srv.URL, &http.Client{Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("NewWebhook() = %v", err)
+	}
+	store, err := identity.NewMemoryReplayStore(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctClient, eca := createCA(cfg, t)
+	g := NewGRPCCAServer(eca, identity.PreventReplay(NewIssuerPool(cfg), store), withCTLog(ctClient), WithAuthorizationWebhook(webhook))
+	ctx := config.With(context.Background(), cfg)
+
+	request := emailSigningRequest(t, emailSigner, emailIssuer)
+	for i := 0; i < 2; i++ {
+		if _, err := g.PreviewSigningCertificate(ctx, request); err != nil {
+			t.Fatalf("PreviewSigningCertificate() = %v", err)
+		}
+	}
+	if n := calls.Load(); n != 0 {
+		t.Fatalf("preview called the authorization webhook %d times", n)
+	}
+	if _, err := g.CreateSigningCertificate(ctx, request); err != nil {
+		t.Fatalf("CreateSigningCertificate() after preview = %v", err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("got %d webhook calls, wanted 1", n)
+	}
+
+	// The issuer policy is checked
+	tok, err := jwt.Signed(emailSigner).Claims(jwt.Claims{
+		Issuer:   emailIssuer,
+		IssuedAt: jwt.NewNumericDate(time.Now()),
+		Expiry:   jwt.NewNumericDate(time.Now().Add(30 * time.Minute)),
+		Subject:  "foo@example.org",
+		Audience: jwt.Audience{"sigstore"},
+	}).Claims(customClaims{Email: "foo@example.org", EmailVerified: true}).Serialize()
+	if err != nil {
+		t.Fatalf("Serialize() = %v", err)
+	}
+	request.Credentials.Credentials = &protobuf.Credentials_OidcIdentityToken{OidcIdentityToken: tok}
+	_, err = g.PreviewSigningCertificate(ctx, request)
+	if status.Code(err) != codes.PermissionDenied || status.Convert(err).Message() != policyDenied {
+		t.Fatalf("expected preview to be denied by policy, got %v", err)
+	}
+}
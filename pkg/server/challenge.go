@@ -0,0 +1,125 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package server
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/sigstore/fulcio/pkg/challenges"
+	"github.com/sigstore/fulcio/pkg/config"
+	fulciogrpc "github.com/sigstore/fulcio/pkg/generated/protobuf"
+	"github.com/sigstore/fulcio/pkg/identity"
+)
+
+// WithChallenges issues challenges from GetChallenge with n, and accepts
+// proofs of possession over them.
+func WithChallenges(n *challenges.NonceIssuer) GRPCCAServerOption {
+	return func(g *grpcaCAServer) {
+		g.nonces = n
+	}
+}
+
+// GetChallenge returns a nonce bound to the identity in the request, for the
+// caller to sign as proof of possession.
+func (g *grpcaCAServer) GetChallenge(ctx context.Context, request *fulciogrpc.GetChallengeRequest) (*fulciogrpc.Challenge, error) {
+	if g.nonces == nil {
+		return nil, handleFulcioGRPCError(ctx, codes.Unimplemented, ReasonChallengesDisabled, errors.New("challenges are not enabled"), challengesNotEnabled)
+	}
+	ctx = withRequestIssuer(ctx, requestToken(ctx, request.Credentials))
+	principal, issuerURL, err := g.identify(ctx, request.Credentials)
+	if err != nil {
+		return nil, err
+	}
+	nonce, expiration, err := g.nonces.Issue(issuerURL, principal.Name(ctx))
+	if err != nil {
+		return nil, handleFulcioGRPCError(ctx, codes.Internal, ReasonInternal, err, issuingChallengeError)
+	}
+	return &fulciogrpc.Challenge{
+		Nonce:      nonce,
+		Expiration: timestamppb.New(expiration),
+	}, nil
+}
+
+// identify authenticates the credentials like verifyCredentials, but without
+// checking the policy of the issuer. Tokens are not used up, since the token
+// is still needed to request the certificate.
+func (g *grpcaCAServer) identify(ctx context.Context, credentials *fulciogrpc.Credentials) (identity.Principal, string, error) {
+	if credentials.GetClientCertificate() != nil {
+		return g.authenticateClientCertificate(ctx)
+	}
+	e := issuerPoolFromContext(ctx, g.IssuerPool).Explain(ctx, requestToken(ctx, credentials))
+	if err := e.Err(); err != nil {
+		return nil, "", handleFulcioGRPCError(ctx, codes.InvalidArgument, tokenErrorReason(err), withField("credentials.oidc_identity_token", err), invalidIdentityToken)
+	}
+	return e.Principal, e.Issuer, nil
+}
+
+// requireChallenge returns whether the issuer requires proofs of possession
+// over a challenge.
+func requireChallenge(ctx context.Context, issuerURL string) bool {
+	cfg := config.FromContext(ctx)
+	if cfg == nil {
+		return false
+	}
+	iss, _ := cfg.GetIssuer(issuerURL)
+	return iss.RequireChallenge
+}
+
+// proofSubject returns the value that the caller must have signed as proof of
+// possession: the challenge if one is given, after checking that it was
+// issued for the principal, or else the principal's name. field is the request
+// field holding the challenge.
+func (g *grpcaCAServer) proofSubject(ctx context.Context, principal identity.Principal, issuerURL, challenge, field string) (string, error) {
+	if challenge == "" {
+		if requireChallenge(ctx, issuerURL) {
+			err := errors.New("no challenge in request")
+			return "", handleFulcioGRPCError(ctx, codes.InvalidArgument, ReasonChallengeRequired, withField(field, err), challengeRequired)
+		}
+		return principal.Name(ctx), nil
+	}
+	if g.nonces == nil {
+		return "", handleFulcioGRPCError(ctx, codes.InvalidArgument, ReasonInvalidChallenge, withField(field, errors.New("challenges are not enabled")), invalidChallenge)
+	}
+	if err := g.nonces.Verify(challenge, issuerURL, principal.Name(ctx)); err != nil {
+		return "", handleFulcioGRPCError(ctx, codes.InvalidArgument, ReasonInvalidChallenge, withField(field, err), invalidChallenge)
+	}
+	return challenge, nil
+}
+
+// consumeChallenge uses up a challenge that was verified by proofSubject, so
+// that a captured proof of possession can't be replayed. The challenge is in
+// the subject of a CSR if fromCSR is set.
+func (g *grpcaCAServer) consumeChallenge(ctx context.Context, challenge string, fromCSR bool) error {
+	if challenge == "" || g.nonces == nil {
+		return nil
+	}
+	field := "public_key_request.challenge"
+	if fromCSR {
+		field = "certificate_signing_request.subject"
+	}
+	err := g.nonces.Consume(ctx, challenge)
+	if errors.Is(err, challenges.ErrNonceReplayed) {
+		return handleFulcioGRPCError(ctx, codes.InvalidArgument, ReasonChallengeReplayed, withField(field, err), challengeReplayed)
+	}
+	if err != nil {
+		return handleFulcioGRPCError(ctx, codes.Internal, ReasonInternal, err, invalidChallenge)
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4/jwt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sigstore/fulcio/pkg/challenges"
+	"github.com/sigstore/fulcio/pkg/config"
+	"github.com/sigstore/fulcio/pkg/generated/protobuf"
+	"github.com/sigstore/fulcio/pkg/identity"
+)
+
+func TestAPIWithChallenge(t *testing.T) {
+	emailSigner, emailIssuer := newOIDCIssuer(t)
+
+	cfg, err := config.Read([]byte(fmt.Sprintf(`{
+		"OIDCIssuers": {
+			%q: {
+				"IssuerURL": %q,
+				"ClientID": "sigstore",
+				"Type": "email",
+				"SingleUseTokens": true,
+				"RequireChallenge": true
+			}
+		}
+	}`, emailIssuer, emailIssuer)))
+	if err != nil {
+		t.Fatalf("config.Read() = %v", err)
+	}
+
+	emailSubject := "foo@example.com"
+	newToken := func(id string) string {
+		tok, err := jwt.Signed(emailSigner).Claims(jwt.Claims{
+			Issuer:   emailIssuer,
+			IssuedAt: jwt.NewNumericDate(time.Now()),
+			Expiry:   jwt.NewNumericDate(time.Now().Add(30 * time.Minute)),
+			Subject:  emailSubject,
+			Audience: jwt.Audience{"sigstore"},
+			ID:       id,
+		}).Claims(customClaims{Email: emailSubject, EmailVerified: true}).Serialize()
+		if err != nil {
+			t.Fatalf("Serialize() = %v", err)
+		}
+		return tok
+	}
+	credentials := func(tok string) *protobuf.Credentials {
+		return &protobuf.Credentials{
+			Credentials: &protobuf.Credentials_OidcIdentityToken{OidcIdentityToken: tok},
+		}
+	}
+
+	ctClient, eca := createCA(cfg, t)
+	store, err := identity.NewMemoryReplayStore(10)
+	if err != nil {
+		t.Fatalf("NewMemoryReplayStore() = %v", err)
+	}
+	nonces, err := challenges.NewNonceIssuer(nil, time.Minute, store)
+	if err != nil {
+		t.Fatalf("NewNonceIssuer() = %v", err)
+	}
+	g := NewGRPCCAServer(eca, identity.PreventReplay(NewIssuerPool(cfg), store), withCTLog(ctClient), WithChallenges(nonces))
+	ctx := config.With(context.Background(), cfg)
+
+	request := func(tok, challenge, signed string) error {
+		pubBytes, proof := generateKeyAndProof(signed, t)
+		_, err := g.CreateSigningCertificate(ctx, &protobuf.CreateSigningCertificateRequest{
+			Credentials: credentials(tok),
+			Key: &protobuf.CreateSigningCertificateRequest_PublicKeyRequest{
+				PublicKeyRequest: &protobuf.PublicKeyRequest{
+					PublicKey: &protobuf.PublicKey{
+						Content: pubBytes,
+					},
+					ProofOfPossession: proof,
+					Challenge:         challenge,
+				},
+			},
+		})
+		return err
+	}
+	expectError := func(err error, message string) {
+		t.Helper()
+		if status.Code(err) != codes.InvalidArgument || status.Convert(err).Message() != message {
+			t.Fatalf("expected %q, got %v", message, err)
+		}
+	}
+
+	// Getting a challenge doesn't use up the single-use token
+	tok := newToken("token-1")
+	challenge, err := g.GetChallenge(ctx, &protobuf.GetChallengeRequest{Credentials: credentials(tok)})
+	if err != nil {
+		t.Fatalf("GetChallenge() = %v", err)
+	}
+	if challenge.Expiration.AsTime().Before(time.Now()) {
+		t.Errorf("challenge already expired at %v", challenge.Expiration.AsTime())
+	}
+
+	// A signed subject is not accepted
+	expectError(request(newToken("token-2"), "", emailSubject), challengeRequired)
+	// The challenge must be signed
+	expectError(request(newToken("token-3"), challenge.Nonce, emailSubject), invalidSignature)
+	// A forged challenge is rejected
+	expectError(request(newToken("token-4"), "forged", "forged"), invalidChallenge)
+
+	if err := request(tok, challenge.Nonce, challenge.Nonce); err != nil {
+		t.Fatalf("CreateSigningCertificate() = %v", err)
+	}
+	// A challenge is only accepted once, even with a fresh token
+	expectError(request(newToken("token-5"), challenge.Nonce, challenge.Nonce), challengeReplayed)
+}
+
+func TestAPIWithChallengeCSR(t *testing.T) {
+	emailSigner, emailIssuer := newOIDCIssuer(t)
+
+	cfg, err := config.Read([]byte(fmt.Sprintf(`{
+		"OIDCIssuers": {
+			%q: {
+				"IssuerURL": %q,
+				"ClientID": "sigstore",
+				"Type": "email",
+				"RequireChallenge": true
+			}
+		}
+	}`, emailIssuer, emailIssuer)))
+	if err != nil {
+		t.Fatalf("config.Read() = %v", err)
+	}
+	emailSubject := "foo@example.com"
+	tok, err := jwt.Signed(emailSigner).Claims(jwt.Claims{
+		Issuer:   emailIssuer,
+		IssuedAt: jwt.NewNumericDate(time.Now()),
+		Expiry:   jwt.NewNumericDate(time.Now().Add(30 * time.Minute)),
+		Subject:  emailSubject,
+		Audience: jwt.Audience{"sigstore"},
+	}).Claims(customClaims{Email: emailSubject, EmailVerified: true}).Serialize()
+	if err != nil {
+		t.Fatalf("Serialize() = %v", err)
+	}
+	credentials := &protobuf.Credentials{
+		Credentials: &protobuf.Credentials_OidcIdentityToken{OidcIdentityToken: tok},
+	}
+
+	ctClient, eca := createCA(cfg, t)
+	store, err := identity.NewMemoryReplayStore(10)
+	if err != nil {
+		t.Fatalf("NewMemoryReplayStore() = %v", err)
+	}
+	nonces, err := challenges.NewNonceIssuer(nil, time.Minute, store)
+	if err != nil {
+		t.Fatalf("NewNonceIssuer() = %v", err)
+	}
+	g := NewGRPCCAServer(eca, NewIssuerPool(cfg), withCTLog(ctClient), WithChallenges(nonces))
+	ctx := config.With(context.Background(), cfg)
+
+	challenge, err := g.GetChallenge(ctx, &protobuf.GetChallengeRequest{Credentials: credentials})
+	if err != nil {
+		t.Fatalf("GetChallenge() = %v", err)
+	}
+
+	request := func(commonName string) error {
+		priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+			Subject: pkix.Name{CommonName: commonName},
+		}, priv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = g.CreateSigningCertificate(ctx, &protobuf.CreateSigningCertificateRequest{
+			Credentials: credentials,
+			Key: &protobuf.CreateSigningCertificateRequest_CertificateSigningRequest{
+				CertificateSigningRequest: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csr}),
+			},
+		})
+		return err
+	}
+
+	if err := request(""); status.Code(err) != codes.InvalidArgument || status.Convert(err).Message() != challengeRequired {
+		t.Fatalf("expected CSR without challenge to be rejected, got %v", err)
+	}
+	if err := request(challenge.Nonce); err != nil {
+		t.Fatalf("CreateSigningCertificate() = %v", err)
+	}
+	if err := request(challenge.Nonce); status.Code(err) != codes.InvalidArgument || status.Convert(err).Message() != challengeReplayed {
+		t.Fatalf("expected reused challenge to be rejected, got %v", err)
+	}
+}
+
+func TestGetChallengeNotEnabled(t *testing.T) {
+	g := NewGRPCCAServer(&FailingCertificateAuthority{}, nil)
+	_, err := g.GetChallenge(context.Background(), &protobuf.GetChallengeRequest{})
+	if status.Code(err) != codes.Unimplemented {
+		t.Fatalf("expected Unimplemented, got %v", err)
+	}
+}
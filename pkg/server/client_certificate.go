@@ -0,0 +1,119 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package server
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/sigstore/fulcio/pkg/config"
+	"github.com/sigstore/fulcio/pkg/identity"
+	"github.com/sigstore/fulcio/pkg/identity/spiffe"
+)
+
+// ClientCertificateVerifier authenticates callers by the TLS client
+// certificate presented on the gRPC connection, for the client certificate
+// issuers of the config.
+type ClientCertificateVerifier struct {
+	issuers []clientCertificateIssuer
+	pool    *x509.CertPool
+}
+
+type clientCertificateIssuer struct {
+	config.ClientCertificateIssuer
+	roots *x509.CertPool
+}
+
+// NewClientCertificateVerifier loads the client CAs of every client
+// certificate issuer in cfg.
+func NewClientCertificateVerifier(cfg *config.FulcioConfig) (*ClientCertificateVerifier, error) {
+	v := &ClientCertificateVerifier{pool: x509.NewCertPool()}
+	for _, iss := range cfg.ClientCertificateIssuers {
+		pemCerts, err := os.ReadFile(filepath.Clean(iss.ClientCAPath))
+		if err != nil {
+			return nil, fmt.Errorf("reading client CAs for %s: %w", iss.IssuerURL, err)
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pemCerts) {
+			return nil, fmt.Errorf("no certificates found in client CAs for %s", iss.IssuerURL)
+		}
+		v.pool.AppendCertsFromPEM(pemCerts)
+		v.issuers = append(v.issuers, clientCertificateIssuer{ClientCertificateIssuer: iss, roots: roots})
+	}
+	// Check issuers in a stable order in case their CAs overlap
+	sort.Slice(v.issuers, func(i, j int) bool { return v.issuers[i].IssuerURL < v.issuers[j].IssuerURL })
+	return v, nil
+}
+
+// ClientCAs returns the CAs of all issuers, which the TLS listener should
+// accept client certificates from.
+func (v *ClientCertificateVerifier) ClientCAs() *x509.CertPool {
+	return v.pool
+}
+
+// Authenticate returns the principal of the client certificate of the
+// connection in ctx, and the URL of the issuer whose CAs it chains to.
+func (v *ClientCertificateVerifier) Authenticate(ctx context.Context) (identity.Principal, string, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, "", errors.New("no peer in request context")
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return nil, "", errors.New("connection does not use TLS")
+	}
+	certs := tlsInfo.State.PeerCertificates
+	if len(certs) == 0 {
+		return nil, "", errors.New("no client certificate was presented")
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	for _, iss := range v.issuers {
+		_, err := certs[0].Verify(x509.VerifyOptions{
+			Roots:         iss.roots,
+			Intermediates: intermediates,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		})
+		if err != nil {
+			continue
+		}
+		principal, err := spiffe.PrincipalFromX509SVID(certs[0], iss.SPIFFETrustDomain, iss.IssuerURL)
+		if err != nil {
+			return nil, "", err
+		}
+		return principal, iss.IssuerURL, nil
+	}
+	return nil, "", errors.New("client certificate is not issued by a configured client CA")
+}
+
+// WithClientCertificateVerifier accepts client certificate credentials that
+// are verified by v.
+func WithClientCertificateVerifier(v *ClientCertificateVerifier) GRPCCAServerOption {
+	return func(g *grpcaCAServer) {
+		g.clientCerts = v
+	}
+}
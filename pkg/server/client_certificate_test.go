@@ -0,0 +1,296 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/sigstore/fulcio/pkg/config"
+	"github.com/sigstore/fulcio/pkg/generated/protobuf"
+	"github.com/sigstore/fulcio/pkg/test"
+)
+
+// issueTLSCert signs a leaf certificate for TLS with the given SANs.
+func issueTLSCert(t *testing.T, parent *x509.Certificate, parentKey *ecdsa.PrivateKey, eku x509.ExtKeyUsage, dnsNames []string, uris []*url.URL) tls.Certificate {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{eku},
+		DNSNames:     dnsNames,
+		URIs:         uris,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, priv.Public(), parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: priv}
+}
+
+// setupClientCertificateTest serves the CA over TLS, accepting client
+// certificates for a SPIFFE trust domain with the given issuer policy, and
+// returns a client presenting clientCert, or no certificate if it is nil.
+// setupClientCertificateTest starts a server accepting client certificates for
+// an issuer configured with the additional JSON fields in issuerFields, and
+// returns a client presenting clientCert.
+func setupClientCertificateTest(t *testing.T, withVerifier bool, issuerFields string, clientCert func(root *x509.Certificate, rootKey *ecdsa.PrivateKey) *tls.Certificate) protobuf.CAClient {
+	t.Helper()
+	root, rootKey, err := test.GenerateRootCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	caPath := filepath.Join(t.TempDir(), "client-ca.pem")
+	caPEM, err := cryptoutils.MarshalCertificatesToPEM([]*x509.Certificate{root})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(caPath, caPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.Read([]byte(`{
+		"ClientCertificateIssuers": {
+			"https://spire.example.com": {
+				"IssuerURL": "https://spire.example.com",
+				"ClientCAPath": "` + caPath + `",
+				"SPIFFETrustDomain": "example.com"` + issuerFields + `
+			}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("config.Read() = %v", err)
+	}
+	verifier, err := NewClientCertificateVerifier(cfg)
+	if err != nil {
+		t.Fatalf("NewClientCertificateVerifier() = %v", err)
+	}
+	var (
+		opts          []GRPCCAServerOption
+		rateLimitOpts []RateLimiterOption
+	)
+	if withVerifier {
+		opts = append(opts, WithClientCertificateVerifier(verifier))
+		rateLimitOpts = append(rateLimitOpts, WithRateLimiterClientCertificates(verifier))
+	}
+	ctClient, eca := createCA(cfg, t)
+
+	serverCert := issueTLSCert(t, root, rootKey, x509.ExtKeyUsageServerAuth, []string{"fulcio.test"}, nil)
+	listener := bufconn.Listen(bufSize)
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(passFulcioConfigThruContext(cfg), NewRateLimiter(NewIssuerPool(cfg), rateLimitOpts...).UnaryServerInterceptor()),
+		grpc.Creds(credentials.NewTLS(&tls.Config{
+			Certificates: []tls.Certificate{serverCert},
+			ClientAuth:   tls.VerifyClientCertIfGiven,
+			ClientCAs:    verifier.ClientCAs(),
+			MinVersion:   tls.VersionTLS13,
+		})))
+	protobuf.RegisterCAServer(s, NewGRPCCAServer(eca, NewIssuerPool(cfg), append([]GRPCCAServerOption{withCTLog(ctClient)}, opts...)...))
+	go func() {
+		if err := s.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			t.Errorf("Server exited with error: %v", err)
+		}
+	}()
+	t.Cleanup(s.Stop)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(root)
+	clientTLS := &tls.Config{RootCAs: roots, ServerName: "fulcio.test", MinVersion: tls.VersionTLS13}
+	if cert := clientCert(root, rootKey); cert != nil {
+		clientTLS.Certificates = []tls.Certificate{*cert}
+	}
+	conn, err := grpc.NewClient("passthrough",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(credentials.NewTLS(clientTLS)))
+	if err != nil {
+		t.Fatalf("grpc.NewClient() = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return protobuf.NewCAClient(conn)
+}
+
+func clientCertificateRequest(t *testing.T, subject string) *protobuf.CreateSigningCertificateRequest {
+	pubKey, proof := generateKeyAndProof(subject, t)
+	return &protobuf.CreateSigningCertificateRequest{
+		Credentials: &protobuf.Credentials{
+			Credentials: &protobuf.Credentials_ClientCertificate{
+				ClientCertificate: &protobuf.ClientCertificateCredentials{},
+			},
+		},
+		Key: &protobuf.CreateSigningCertificateRequest_PublicKeyRequest{
+			PublicKeyRequest: &protobuf.PublicKeyRequest{
+				PublicKey: &protobuf.PublicKey{
+					Content: pubKey,
+				},
+				ProofOfPossession: proof,
+			},
+		},
+	}
+}
+
+func TestAPIWithClientCertificate(t *testing.T) {
+	spiffeID := "spiffe://example.com/build/agent"
+	client := setupClientCertificateTest(t, true, "", func(root *x509.Certificate, rootKey *ecdsa.PrivateKey) *tls.Certificate {
+		id, _ := url.Parse(spiffeID)
+		cert := issueTLSCert(t, root, rootKey, x509.ExtKeyUsageClientAuth, nil, []*url.URL{id})
+		return &cert
+	})
+
+	resp, err := client.CreateSigningCertificate(context.Background(), clientCertificateRequest(t, spiffeID))
+	if err != nil {
+		t.Fatalf("CreateSigningCertificate() = %v", err)
+	}
+	chain := resp.GetSignedCertificateEmbeddedSct().GetChain()
+	if chain == nil || len(chain.Certificates) == 0 {
+		t.Fatal("expected a certificate chain")
+	}
+	certs, err := cryptoutils.UnmarshalCertificatesFromPEM([]byte(chain.Certificates[0]))
+	if err != nil {
+		t.Fatalf("parsing leaf certificate: %v", err)
+	}
+	if len(certs[0].URIs) != 1 || certs[0].URIs[0].String() != spiffeID {
+		t.Errorf("got URIs %v, wanted %s", certs[0].URIs, spiffeID)
+	}
+	issuerExt, found := findCustomExtension(certs[0], []int{1, 3, 6, 1, 4, 1, 57264, 1, 1})
+	if !found || string(issuerExt.Value) != "https://spire.example.com" {
+		t.Errorf("expected issuer https://spire.example.com, got %q", issuerExt.Value)
+	}
+}
+
+func TestAPIWithClientCertificateRejected(t *testing.T) {
+	spiffeID := "spiffe://example.com/build/agent"
+	tests := map[string]struct {
+		withVerifier bool
+		clientCert   func(root *x509.Certificate, rootKey *ecdsa.PrivateKey) *tls.Certificate
+	}{
+		"no client certificate": {
+			withVerifier: true,
+			clientCert:   func(*x509.Certificate, *ecdsa.PrivateKey) *tls.Certificate { return nil },
+		},
+		"client certificates not enabled": {
+			withVerifier: false,
+			clientCert: func(root *x509.Certificate, rootKey *ecdsa.PrivateKey) *tls.Certificate {
+				id, _ := url.Parse(spiffeID)
+				cert := issueTLSCert(t, root, rootKey, x509.ExtKeyUsageClientAuth, nil, []*url.URL{id})
+				return &cert
+			},
+		},
+		"wrong trust domain": {
+			withVerifier: true,
+			clientCert: func(root *x509.Certificate, rootKey *ecdsa.PrivateKey) *tls.Certificate {
+				id, _ := url.Parse("spiffe://other.example.com/build/agent")
+				cert := issueTLSCert(t, root, rootKey, x509.ExtKeyUsageClientAuth, nil, []*url.URL{id})
+				return &cert
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := setupClientCertificateTest(t, tc.withVerifier, "", tc.clientCert)
+			_, err := client.CreateSigningCertificate(context.Background(), clientCertificateRequest(t, spiffeID))
+			if status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("expected InvalidArgument, got %v", err)
+			}
+			if msg := status.Convert(err).Message(); msg != invalidClientCertificate {
+				t.Errorf("got message %q, wanted %q", msg, invalidClientCertificate)
+			}
+		})
+	}
+}
+
+func TestAPIWithClientCertificatePolicy(t *testing.T) {
+	spiffeID := "spiffe://example.com/build/agent"
+	clientCert := func(root *x509.Certificate, rootKey *ecdsa.PrivateKey) *tls.Certificate {
+		id, _ := url.Parse(spiffeID)
+		cert := issueTLSCert(t, root, rootKey, x509.ExtKeyUsageClientAuth, nil, []*url.URL{id})
+		return &cert
+	}
+
+	client := setupClientCertificateTest(t, true, `, "Policy": `+strconv.Quote(`principal.startsWith("spiffe://example.com/deploy/")`), clientCert)
+	_, err := client.CreateSigningCertificate(context.Background(), clientCertificateRequest(t, spiffeID))
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied, got %v", err)
+	}
+	if msg := status.Convert(err).Message(); msg != policyDenied {
+		t.Errorf("got message %q, wanted %q", msg, policyDenied)
+	}
+
+	client = setupClientCertificateTest(t, true, `, "Policy": `+strconv.Quote(`principal.startsWith("spiffe://example.com/build/")`), clientCert)
+	if _, err := client.CreateSigningCertificate(context.Background(), clientCertificateRequest(t, spiffeID)); err != nil {
+		t.Fatalf("CreateSigningCertificate() = %v", err)
+	}
+}
+
+func TestAPIWithClientCertificateRateLimit(t *testing.T) {
+	spiffeID := "spiffe://example.com/build/agent"
+	client := setupClientCertificateTest(t, true, `, "RateLimit": {"IdentityPerMinute": 1, "IdentityBurst": 1}`, func(root *x509.Certificate, rootKey *ecdsa.PrivateKey) *tls.Certificate {
+		id, _ := url.Parse(spiffeID)
+		cert := issueTLSCert(t, root, rootKey, x509.ExtKeyUsageClientAuth, nil, []*url.URL{id})
+		return &cert
+	})
+
+	if _, err := client.CreateSigningCertificate(context.Background(), clientCertificateRequest(t, spiffeID)); err != nil {
+		t.Fatalf("CreateSigningCertificate() = %v", err)
+	}
+	// The SPIFFE ID has used up its burst
+	_, err := client.CreateSigningCertificate(context.Background(), clientCertificateRequest(t, spiffeID))
+	if status.Code(err) != codes.ResourceExhausted || status.Convert(err).Message() != identityRateLimitExceeded {
+		t.Fatalf("expected identity rate limit to be exceeded, got %v", err)
+	}
+}
+
+// namedPrincipal is a principal that only has a name
+type namedPrincipal string
+
+func (p namedPrincipal) Name(context.Context) string {
+	return string(p)
+}
+
+func (p namedPrincipal) Embed(context.Context, *x509.Certificate) error {
+	return nil
+}
+
+// Tests that policies fail closed when there is no configuration to read them from
+func TestCheckPolicyWithoutConfig(t *testing.T) {
+	err := checkPolicy(context.Background(), namedPrincipal("spiffe://example.com/build/agent"), "https://spire.example.com", nil)
+	if status.Code(err) != codes.Internal || status.Convert(err).Message() != loadingFulcioConfigurationError {
+		t.Fatalf("expected policy check without configuration to fail, got %v", err)
+	}
+}
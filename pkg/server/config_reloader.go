@@ -0,0 +1,225 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/sigstore/fulcio/pkg/config"
+	"github.com/sigstore/fulcio/pkg/identity"
+	"github.com/sigstore/fulcio/pkg/log"
+)
+
+// configReloadDelay is how long to wait after a change to the configuration
+// file before reloading it, so that a file written in several steps is read
+// once it is complete.
+const configReloadDelay = 500 * time.Millisecond
+
+// ConfigReloader holds the current FulcioConfig and the IssuerPool built from
+// it, and replaces both when the configuration file changes. A configuration
+// that fails to load or validate, or with an OIDC issuer that can't be
+// discovered, is rejected and the previous one is kept.
+//
+// The OIDC and meta issuers, the settings read from the context of a request
+// and the issuers probed by health checks follow the current configuration.
+// Anything derived from the configuration at startup, such as the client
+// certificate issuers and the trust of the Kubernetes cluster's CA, requires a
+// restart to change.
+type ConfigReloader struct {
+	path    string
+	newPool func(*config.FulcioConfig) identity.IssuerPool
+	current atomic.Pointer[configSnapshot]
+
+	// started numbers reloads in the order they start
+	started atomic.Uint64
+
+	// mu guards digest and stored
+	mu     sync.Mutex
+	digest [sha256.Size]byte
+	// stored is the number of the reload of the current configuration
+	stored uint64
+}
+
+type configSnapshot struct {
+	cfg *config.FulcioConfig
+	ip  identity.IssuerPool
+}
+
+// NewConfigReloader returns a ConfigReloader serving cfg, which was loaded
+// from path. newPool builds the IssuerPool for a configuration; it is called
+// for cfg and again for every configuration that is reloaded.
+func NewConfigReloader(path string, cfg *config.FulcioConfig, newPool func(*config.FulcioConfig) identity.IssuerPool) *ConfigReloader {
+	r := &ConfigReloader{
+		path:    filepath.Clean(path),
+		newPool: newPool,
+	}
+	// The file may not exist when the default configuration is used, in
+	// which case any configuration written later is loaded
+	if b, err := os.ReadFile(r.path); err == nil {
+		r.digest = sha256.Sum256(b)
+	}
+	r.current.Store(&configSnapshot{cfg: cfg, ip: newPool(cfg)})
+	return r
+}
+
+// Config returns the current configuration.
+func (r *ConfigReloader) Config() *config.FulcioConfig {
+	if r == nil {
+		return nil
+	}
+	return r.current.Load().cfg
+}
+
+// IssuerPool returns the IssuerPool for the current configuration.
+func (r *ConfigReloader) IssuerPool() identity.IssuerPool {
+	if r == nil {
+		return nil
+	}
+	return r.current.Load().ip
+}
+
+// With returns a copy of ctx carrying the current configuration and its
+// IssuerPool, so that a request is served by a single version of the
+// configuration even if it is reloaded while the request is in flight.
+func (r *ConfigReloader) With(ctx context.Context) context.Context {
+	if r == nil {
+		return config.With(ctx, nil)
+	}
+	s := r.current.Load()
+	ctx = config.With(ctx, s.cfg)
+	return context.WithValue(ctx, issuerPoolKey{}, s.ip)
+}
+
+// Reload reads and validates the configuration file and, if its contents have
+// changed, replaces the current configuration and IssuerPool. On error the
+// current configuration is kept.
+func (r *ConfigReloader) Reload() error {
+	n := r.started.Add(1)
+
+	b, err := os.ReadFile(r.path)
+	if err != nil {
+		return r.reloadFailed(fmt.Errorf("read file: %w", err))
+	}
+	digest := sha256.Sum256(b)
+	r.mu.Lock()
+	unchanged := digest == r.digest
+	r.mu.Unlock()
+	if unchanged {
+		return nil
+	}
+
+	// The issuers are discovered without holding the lock, which may take
+	// up to the discovery timeout
+	cfg, err := config.Read(b)
+	if err != nil {
+		return r.reloadFailed(err)
+	}
+	// Tokens from an issuer that could not be discovered, such as during an
+	// outage of the issuer, would be rejected until the next reload
+	if err := cfg.DiscoveryErr(); err != nil {
+		return r.reloadFailed(err)
+	}
+	ip := r.newPool(cfg)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// A reload that started later has read the file since
+	if n < r.stored {
+		return nil
+	}
+	r.current.Store(&configSnapshot{cfg: cfg, ip: ip})
+	r.digest = digest
+	r.stored = n
+
+	metricConfigReloads.WithLabelValues("success").Inc()
+	metricConfigLastReloadSuccess.SetToCurrentTime()
+	log.Logger.Infow("reloaded configuration", "path", r.path, "sha256", fmt.Sprintf("%x", digest),
+		"oidcIssuers", len(cfg.OIDCIssuers), "metaIssuers", len(cfg.MetaIssuers))
+	return nil
+}
+
+func (r *ConfigReloader) reloadFailed(err error) error {
+	metricConfigReloads.WithLabelValues("failure").Inc()
+	log.Logger.Errorw("error reloading configuration, keeping the current configuration", "path", r.path, "error", err)
+	return fmt.Errorf("reloading %s: %w", r.path, err)
+}
+
+// Watch reloads the configuration whenever the configuration file changes,
+// until ctx is cancelled.
+//
+// The directory containing the file is watched rather than the file itself,
+// since editors and Kubernetes replace files rather than writing to them. A
+// mounted ConfigMap is updated by atomically swapping a symlink to a new
+// directory of files, which changes the file the path resolves to without any
+// event for the path. Every event in the directory therefore triggers a
+// reload, which is skipped if the contents of the file are unchanged.
+func (r *ConfigReloader) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := watcher.Add(filepath.Dir(r.path)); err != nil {
+		watcher.Close()
+		return fmt.Errorf("watching %s: %w", filepath.Dir(r.path), err)
+	}
+
+	go func() {
+		defer watcher.Close()
+		timer := time.NewTimer(configReloadDelay)
+		timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case _, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				timer.Reset(configReloadDelay)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Logger.Errorw("error watching configuration", "path", r.path, "error", err)
+			case <-timer.C:
+				// Errors are logged and counted by Reload
+				_ = r.Reload()
+			}
+		}
+	}()
+	return nil
+}
+
+type issuerPoolKey struct{}
+
+// issuerPoolFromContext returns the IssuerPool added to ctx by a
+// ConfigReloader, or fallback if there is none.
+func issuerPoolFromContext(ctx context.Context, fallback identity.IssuerPool) identity.IssuerPool {
+	if ip, ok := ctx.Value(issuerPoolKey{}).(identity.IssuerPool); ok {
+		return ip
+	}
+	return fallback
+}
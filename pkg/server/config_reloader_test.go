@@ -0,0 +1,163 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sigstore/fulcio/pkg/config"
+)
+
+func emailIssuerConfig(issuerURL string) string {
+	return fmt.Sprintf(`{
+		"OIDCIssuers": {
+			%q: {
+				"IssuerURL": %q,
+				"ClientID": "sigstore",
+				"Type": "email"
+			}
+		}
+	}`, issuerURL, issuerURL)
+}
+
+func writeConfig(t *testing.T, path, contents string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("WriteFile() = %v", err)
+	}
+}
+
+func TestConfigReloader(t *testing.T) {
+	emailSigner, emailIssuer := newOIDCIssuer(t)
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	writeConfig(t, path, emailIssuerConfig("https://other.example.com"))
+	initial, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("config.Load() = %v", err)
+	}
+	r := NewConfigReloader(path, initial, NewIssuerPool)
+
+	// Nothing has changed
+	if err := r.Reload(); err != nil {
+		t.Fatalf("Reload() = %v", err)
+	}
+	if r.Config() != initial {
+		t.Fatal("unchanged configuration was replaced")
+	}
+
+	ctClient, eca := createCA(initial, t)
+	g := NewGRPCCAServer(eca, r.IssuerPool(), withCTLog(ctClient))
+	before := r.With(context.Background())
+	if _, err := g.CreateSigningCertificate(before, emailSigningRequest(t, emailSigner, emailIssuer)); err == nil {
+		t.Fatal("expected error for unconfigured issuer")
+	}
+
+	// An invalid configuration is rejected and the previous one kept
+	writeConfig(t, path, `{"OIDCIssuers": {"https://example.com": {"IssuerURL": "https://example.com", "Type": "unknown"}}}`)
+	if err := r.Reload(); err == nil {
+		t.Fatal("expected error reloading invalid configuration")
+	}
+	if r.Config() != initial {
+		t.Fatal("invalid configuration replaced the current one")
+	}
+
+	// So is a configuration with an issuer that can't be discovered
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+	writeConfig(t, path, emailIssuerConfig(unreachable.URL))
+	if err := r.Reload(); err == nil {
+		t.Fatal("expected error reloading configuration with an undiscoverable issuer")
+	}
+	if r.Config() != initial {
+		t.Fatal("configuration with an undiscoverable issuer replaced the current one")
+	}
+
+	writeConfig(t, path, emailIssuerConfig(emailIssuer))
+	if err := r.Reload(); err != nil {
+		t.Fatalf("Reload() = %v", err)
+	}
+	if _, ok := r.Config().GetIssuer(emailIssuer); !ok {
+		t.Fatal("reloaded configuration is missing the new issuer")
+	}
+	if _, err := g.CreateSigningCertificate(r.With(context.Background()), emailSigningRequest(t, emailSigner, emailIssuer)); err != nil {
+		t.Fatalf("CreateSigningCertificate() = %v", err)
+	}
+	// Requests already in flight keep the configuration they started with
+	if _, err := g.CreateSigningCertificate(before, emailSigningRequest(t, emailSigner, emailIssuer)); err == nil {
+		t.Fatal("expected error for request using the previous configuration")
+	}
+}
+
+// TestConfigReloaderWatch updates the configuration the way Kubernetes updates
+// a mounted ConfigMap, by swapping the ..data symlink to a new directory.
+func TestConfigReloaderWatch(t *testing.T) {
+	_, first := newOIDCIssuer(t)
+	_, second := newOIDCIssuer(t)
+	dir := t.TempDir()
+	for i, issuerURL := range []string{first, second} {
+		version := filepath.Join(dir, fmt.Sprintf("..version_%d", i))
+		if err := os.Mkdir(version, 0o700); err != nil {
+			t.Fatal(err)
+		}
+		writeConfig(t, filepath.Join(version, "config.json"), emailIssuerConfig(issuerURL))
+	}
+	if err := os.Symlink("..version_0", filepath.Join(dir, "..data")); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "config.json")
+	if err := os.Symlink(filepath.Join("..data", "config.json"), path); err != nil {
+		t.Fatal(err)
+	}
+
+	initial, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("config.Load() = %v", err)
+	}
+	r := NewConfigReloader(path, initial, NewIssuerPool)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := r.Watch(ctx); err != nil {
+		t.Fatalf("Watch() = %v", err)
+	}
+
+	if err := os.Symlink("..version_1", filepath.Join(dir, "..data_tmp")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		if _, ok := r.Config().GetIssuer(second); ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("configuration was not reloaded")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if _, ok := r.Config().GetIssuer(first); ok {
+		t.Error("reloaded configuration still has the previous issuer")
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	ct "github.com/google/certificate-transparency-go"
+	ctclient "github.com/google/certificate-transparency-go/client"
+	"github.com/google/certificate-transparency-go/jsonclient"
+	ctx509 "github.com/google/certificate-transparency-go/x509"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sigstore/fulcio/pkg/config"
+	"github.com/sigstore/fulcio/pkg/ctl"
+	"github.com/sigstore/fulcio/pkg/generated/protobuf"
+)
+
+// emailSigningRequest returns a request for a certificate for a public key
+// with a token for foo@example.com from issuer
+func emailSigningRequest(t *testing.T, signer jose.Signer, issuer string) *protobuf.CreateSigningCertificateRequest {
+	t.Helper()
+	emailSubject := "foo@example.com"
+	tok, err := jwt.Signed(signer).Claims(jwt.Claims{
+		Issuer:   issuer,
+		IssuedAt: jwt.NewNumericDate(time.Now()),
+		Expiry:   jwt.NewNumericDate(time.Now().Add(30 * time.Minute)),
+		Subject:  emailSubject,
+		Audience: jwt.Audience{"sigstore"},
+	}).Claims(customClaims{Email: emailSubject, EmailVerified: true}).Serialize()
+	if err != nil {
+		t.Fatalf("Serialize() = %v", err)
+	}
+	pubBytes, proof := generateKeyAndProof(emailSubject, t)
+	return &protobuf.CreateSigningCertificateRequest{
+		Credentials: &protobuf.Credentials{
+			Credentials: &protobuf.Credentials_OidcIdentityToken{
+				OidcIdentityToken: tok,
+			},
+		},
+		Key: &protobuf.CreateSigningCertificateRequest_PublicKeyRequest{
+			PublicKeyRequest: &protobuf.PublicKeyRequest{
+				PublicKey: &protobuf.PublicKey{
+					Content: pubBytes,
+				},
+				ProofOfPossession: proof,
+			},
+		},
+	}
+}
+
+// failingCTLog rejects every submission
+type failingCTLog struct{}
+
+func (failingCTLog) AddChain(context.Context, []ct.ASN1Cert) (*ct.SignedCertificateTimestamp, error) {
+	return nil, errors.New("log unavailable")
+}
+
+func (failingCTLog) AddPreChain(context.Context, []ct.ASN1Cert) (*ct.SignedCertificateTimestamp, error) {
+	return nil, errors.New("log unavailable")
+}
+
+func TestAPIWithMultipleCTLogs(t *testing.T) {
+	emailSigner, emailIssuer := newOIDCIssuer(t)
+
+	cfg, err := config.Read([]byte(fmt.Sprintf(`{
+		"OIDCIssuers": {
+			%q: {
+				"IssuerURL": %q,
+				"ClientID": "sigstore",
+				"Type": "email"
+			}
+		}
+	}`, emailIssuer, emailIssuer)))
+	if err != nil {
+		t.Fatalf("config.Read() = %v", err)
+	}
+
+	newClient := func(srv *httptest.Server) *ctclient.LogClient {
+		t.Cleanup(srv.Close)
+		client, err := ctclient.New(srv.URL, &http.Client{Timeout: 5 * time.Second}, jsonclient.Options{})
+		if err != nil {
+			t.Fatalf("ctclient.New() = %v", err)
+		}
+		return client
+	}
+	logs := []ctl.Log{newClient(fakeCTLogServer(t)), failingCTLog{}, newClient(fakeCTLogServer(t))}
+
+	request := emailSigningRequest(t, emailSigner, emailIssuer)
+	ctx := config.With(context.Background(), cfg)
+
+	// Two of the three logs return an SCT, which are both embedded
+	quorum, err := ctl.NewLogs(2, logs...)
+	if err != nil {
+		t.Fatalf("NewLogs() = %v", err)
+	}
+	_, eca := createCA(cfg, t)
+	g := NewGRPCCAServer(eca, NewIssuerPool(cfg), WithCTLogs(quorum))
+	resp, err := g.CreateSigningCertificate(ctx, request)
+	if err != nil {
+		t.Fatalf("CreateSigningCertificate() = %v", err)
+	}
+	block, _ := pem.Decode([]byte(resp.GetSignedCertificateEmbeddedSct().GetChain().GetCertificates()[0]))
+	cert, err := ctx509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("ParseCertificate() = %v", err)
+	}
+	if len(cert.SCTList.SCTList) != 2 {
+		t.Errorf("got %d embedded SCTs, wanted 2", len(cert.SCTList.SCTList))
+	}
+
+	// Every log is required by default
+	all, err := ctl.NewLogs(0, logs...)
+	if err != nil {
+		t.Fatalf("NewLogs() = %v", err)
+	}
+	g = NewGRPCCAServer(eca, NewIssuerPool(cfg), WithCTLogs(all))
+	_, err = g.CreateSigningCertificate(ctx, request)
+	if status.Code(err) != codes.Internal || status.Convert(err).Message() != failedToEnterCertInCTL {
+		t.Fatalf("expected quorum failure, got %v", err)
+	}
+}
+
+func TestAPIWithUnverifiableSCT(t *testing.T) {
+	emailSigner, emailIssuer := newOIDCIssuer(t)
+
+	cfg, err := config.Read([]byte(fmt.Sprintf(`{
+		"OIDCIssuers": {
+			%q: {
+				"IssuerURL": %q,
+				"ClientID": "sigstore",
+				"Type": "email"
+			}
+		}
+	}`, emailIssuer, emailIssuer)))
+	if err != nil {
+		t.Fatalf("config.Read() = %v", err)
+	}
+
+	// The fake log's SCTs are not signed by this key
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(priv.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := fakeCTLogServer(t)
+	t.Cleanup(srv.Close)
+	client, err := ctclient.New(srv.URL, &http.Client{Timeout: 5 * time.Second}, jsonclient.Options{
+		PublicKey: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+	})
+	if err != nil {
+		t.Fatalf("ctclient.New() = %v", err)
+	}
+
+	_, eca := createCA(cfg, t)
+	g := NewGRPCCAServer(eca, NewIssuerPool(cfg), withCTLog(client))
+	_, err = g.CreateSigningCertificate(config.With(context.Background(), cfg), emailSigningRequest(t, emailSigner, emailIssuer))
+	if status.Code(err) != codes.Internal || status.Convert(err).Message() != failedToVerifySCT {
+		t.Fatalf("expected SCT verification failure, got %v", err)
+	}
+}
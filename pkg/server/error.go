@@ -17,10 +17,16 @@ package server
 
 import (
 	"context"
+	"errors"
 
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/sigstore/fulcio/pkg/authz"
+	"github.com/sigstore/fulcio/pkg/identity"
 	"github.com/sigstore/fulcio/pkg/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
 )
 
 const (
@@ -29,8 +35,10 @@ const (
 	invalidCSR             = "The certificate signing request could not be parsed"
 	failedToEnterCertInCTL = "Error entering certificate in CTL"
 	failedToMarshalSCT     = "Error marshaling signed certificate timestamp"
+	failedToVerifySCT      = "Error verifying signed certificate timestamp"
 	failedToMarshalCert    = "Error marshaling code signing certificate"
 	insecurePublicKey      = "The public key supplied in the request is insecure"
+	keyPolicyViolation     = "The public key supplied in the request is not allowed for this OIDC issuer"
 	//nolint
 	invalidCredentials = "There was an error processing the credentials for this request"
 	// nolint
@@ -38,10 +46,163 @@ const (
 	genericCAError                          = "error communicating with CA backend"
 	retrieveTrustBundleCAError              = "error retrieving trust bundle from CA backend"
 	marshalingCertificateChainBundleCAError = "error marshaling the certificate chain of the bundle"
+	marshalingTrustedRootError              = "error marshaling the trusted root"
 	loadingFulcioConfigurationError         = "error loading fulcio configuration"
+	noKeysRequested                         = "At least one key must be supplied in the request"
+	tooManyKeysRequested                    = "Too many keys were supplied in the request"
+	invalidRequestedValidity                = "The requested certificate validity is invalid"
+	certificateStoreNotEnabled              = "This instance does not store issued certificates"
+	certificateLookupNotEnabled             = "This instance does not serve lookups of issued certificates"
+	certificateNotFound                     = "No certificate with the requested serial number was found"
+	certificateStoreError                   = "error retrieving certificates from the certificate store"
+	invalidSearchRequest                    = "The certificate search request is invalid"
+	identityRateLimitExceeded               = "Too many certificates were requested for this identity, try again later"
+	issuerRateLimitExceeded                 = "Too many certificates were requested for this OIDC issuer, try again later"
+	identityTokenReplayed                   = "The identity token has already been used, request a new token"
+	invalidClientCertificate                = "There was an error processing the client certificate"
+	invalidChallenge                        = "The challenge supplied in the request is invalid or has expired"
+	challengeReplayed                       = "The challenge has already been used, request a new challenge"
+	unsupportedSignatureAlgorithm           = "The signature algorithm supplied in the request is not supported"
+	challengeRequired                       = "A challenge from GetChallenge must be signed as proof of possession for this OIDC issuer"
+	challengesNotEnabled                    = "This instance does not issue challenges"
+	issuingChallengeError                   = "error issuing challenge"
+	policyDenied                            = "The identity is not allowed to request certificates by the policy of this OIDC issuer"
+	authorizationDenied                     = "The certificate request was denied by the authorization policy"
+	authorizationUnavailable                = "The certificate request could not be authorized, try again later"
 )
 
-func handleFulcioGRPCError(ctx context.Context, code codes.Code, err error, message string, fields ...interface{}) error {
-	log.ContextLogger(ctx).Errorw(err.Error(), append([]interface{}{"code", code, "clientMessage", message, "error", err}, fields...)...)
-	return status.Error(code, message)
+// ErrorDomain is the domain of the google.rpc.ErrorInfo details of errors
+// returned by Fulcio.
+const ErrorDomain = "fulcio.sigstore.dev"
+
+// Reasons set in the google.rpc.ErrorInfo details of errors. Unlike error
+// messages, reasons are stable and can be relied on by clients.
+const (
+	ReasonMalformedToken            = "MALFORMED_TOKEN"
+	ReasonUnsupportedIssuer         = "UNSUPPORTED_ISSUER"
+	ReasonTokenExpired              = "TOKEN_EXPIRED"
+	ReasonAudienceMismatch          = "AUDIENCE_MISMATCH"
+	ReasonTokenVerificationFailed   = "TOKEN_VERIFICATION_FAILED"
+	ReasonInvalidClaims             = "INVALID_CLAIMS"
+	ReasonTokenReplayed             = "TOKEN_REPLAYED"
+	ReasonInvalidClientCertificate  = "INVALID_CLIENT_CERTIFICATE"
+	ReasonInvalidChallenge          = "INVALID_CHALLENGE"
+	ReasonChallengeReplayed         = "CHALLENGE_REPLAYED"
+	ReasonUnsupportedAlgorithm      = "UNSUPPORTED_ALGORITHM"
+	ReasonChallengeRequired         = "CHALLENGE_REQUIRED"
+	ReasonChallengesDisabled        = "CHALLENGES_DISABLED"
+	ReasonInvalidPublicKey          = "INVALID_PUBLIC_KEY"
+	ReasonWeakKey                   = "WEAK_KEY"
+	ReasonKeyPolicyViolation        = "KEY_POLICY_VIOLATION"
+	ReasonPolicyDenied              = "POLICY_DENIED"
+	ReasonAuthorizationDenied       = "AUTHORIZATION_DENIED"
+	ReasonAuthorizationUnavailable  = "AUTHORIZATION_UNAVAILABLE"
+	ReasonInvalidCSR                = "INVALID_CSR"
+	ReasonPOPSignatureInvalid       = "POP_SIGNATURE_INVALID"
+	ReasonInvalidRequest            = "INVALID_REQUEST"
+	ReasonIdentityRateLimited       = "IDENTITY_RATE_LIMITED"
+	ReasonIssuerRateLimited         = "ISSUER_RATE_LIMITED"
+	ReasonCAError                   = "CA_ERROR"
+	ReasonCTSubmissionFailed        = "CT_SUBMISSION_FAILED"
+	ReasonSCTVerificationFailed     = "SCT_VERIFICATION_FAILED"
+	ReasonCertificateStoreDisabled  = "CERTIFICATE_STORE_DISABLED"
+	ReasonCertificateLookupDisabled = "CERTIFICATE_LOOKUP_DISABLED"
+	ReasonCertificateNotFound       = "CERTIFICATE_NOT_FOUND"
+	ReasonCertificateStoreError     = "CERTIFICATE_STORE_ERROR"
+	ReasonInternal                  = "INTERNAL"
+)
+
+// tokenErrorReason returns the reason a token was rejected.
+func tokenErrorReason(err error) string {
+	var expired *oidc.TokenExpiredError
+	switch {
+	case errors.Is(err, identity.ErrMalformedToken):
+		return ReasonMalformedToken
+	case errors.Is(err, identity.ErrUnsupportedIssuer):
+		return ReasonUnsupportedIssuer
+	case errors.As(err, &expired):
+		return ReasonTokenExpired
+	case errors.Is(err, identity.ErrAudienceMismatch):
+		return ReasonAudienceMismatch
+	case errors.Is(err, identity.ErrTokenVerification):
+		return ReasonTokenVerificationFailed
+	default:
+		// Issuers verify the token before reading its claims
+		return ReasonInvalidClaims
+	}
+}
+
+// fieldError marks an error as caused by the value of a request field.
+type fieldError struct {
+	field string
+	err   error
+}
+
+func (e *fieldError) Error() string {
+	return e.err.Error()
+}
+
+func (e *fieldError) Unwrap() error {
+	return e.err
+}
+
+// withField marks err as caused by the value of field, which is reported in
+// the google.rpc.BadRequest details of the error returned to the client.
+func withField(field string, err error) error {
+	return &fieldError{field: field, err: err}
+}
+
+type requestIssuerKey struct{}
+
+// withRequestIssuer records the unverified issuer of token, which is reported
+// in the details of errors.
+func withRequestIssuer(ctx context.Context, token string) context.Context {
+	issuerURL, err := identity.ExtractIssuerURL(token)
+	if err != nil || issuerURL == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, requestIssuerKey{}, issuerURL)
+}
+
+// errorStatus returns a status with ErrorInfo details for the error with the
+// given reason, and BadRequest details if it was caused by a request field.
+func errorStatus(ctx context.Context, code codes.Code, reason string, err error, message string) *status.Status {
+	info := &errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: ErrorDomain,
+	}
+	if issuerURL, ok := ctx.Value(requestIssuerKey{}).(string); ok {
+		info.Metadata = map[string]string{"issuer": issuerURL}
+	}
+	// The reason for denying a request is written by the operator for clients
+	var denied *authz.DeniedError
+	if errors.As(err, &denied) && denied.Reason != "" {
+		if info.Metadata == nil {
+			info.Metadata = map[string]string{}
+		}
+		info.Metadata["denialReason"] = denied.Reason
+	}
+	details := []protoadapt.MessageV1{info}
+	var fe *fieldError
+	if errors.As(err, &fe) {
+		details = append(details, &errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{
+				Field:       fe.field,
+				Description: message,
+			}},
+		})
+	}
+
+	st := status.New(code, message)
+	if detailed, err := st.WithDetails(details...); err == nil {
+		st = detailed
+	}
+	return st
+}
+
+// handleFulcioGRPCError logs err and returns an error for the client with the
+// given code, one of the Reason constants, and message.
+func handleFulcioGRPCError(ctx context.Context, code codes.Code, reason string, err error, message string, fields ...interface{}) error {
+	log.ContextLogger(ctx).Errorw(err.Error(), append([]interface{}{"code", code, "reason", reason, "clientMessage", message, "error", err}, fields...)...)
+	return errorStatus(ctx, code, reason, err, message).Err()
 }
@@ -0,0 +1,171 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sigstore/fulcio/pkg/config"
+	"github.com/sigstore/fulcio/pkg/generated/protobuf"
+	"github.com/sigstore/fulcio/pkg/identity"
+)
+
+func TestTokenErrorReason(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		want string
+	}{
+		"malformed token": {
+			fmt.Errorf("%w, expected 3 parts got 1", identity.ErrMalformedToken), ReasonMalformedToken,
+		},
+		"unsupported issuer": {
+			fmt.Errorf("%w: https://example.com", identity.ErrUnsupportedIssuer), ReasonUnsupportedIssuer,
+		},
+		"expired token": {
+			fmt.Errorf("authorizing github issuer: %w: %w", identity.ErrTokenVerification, &oidc.TokenExpiredError{Expiry: time.Now()}), ReasonTokenExpired,
+		},
+		"wrong audience": {
+			fmt.Errorf("%w: %w: %w", identity.ErrTokenVerification, identity.ErrAudienceMismatch, errors.New("oidc: invalid audience")), ReasonAudienceMismatch,
+		},
+		"invalid signature": {
+			fmt.Errorf("%w: %w", identity.ErrTokenVerification, errors.New("failed to verify signature")), ReasonTokenVerificationFailed,
+		},
+		"missing claim": {
+			errors.New("missing job_workflow_ref claim in ID token"), ReasonInvalidClaims,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tokenErrorReason(test.err); got != test.want {
+				t.Errorf("tokenErrorReason() = %q, wanted %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestErrorStatusDetails(t *testing.T) {
+	ctx := withRequestIssuer(context.Background(), "eyJhbGciOiJub25lIn0.eyJpc3MiOiJodHRwczovL2V4YW1wbGUuY29tIn0.sig")
+	err := handleFulcioGRPCError(ctx, codes.InvalidArgument, ReasonPOPSignatureInvalid, withField("public_key_request.proof_of_possession", errors.New("bad signature")), invalidSignature)
+
+	var (
+		info       *errdetails.ErrorInfo
+		badRequest *errdetails.BadRequest
+	)
+	for _, detail := range statusDetails(t, err) {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			info = detail
+		case *errdetails.BadRequest:
+			badRequest = detail
+		}
+	}
+	if info == nil || info.Reason != ReasonPOPSignatureInvalid || info.Domain != ErrorDomain || info.Metadata["issuer"] != "https://example.com" {
+		t.Errorf("unexpected ErrorInfo %v", info)
+	}
+	if badRequest == nil || len(badRequest.FieldViolations) != 1 || badRequest.FieldViolations[0].Field != "public_key_request.proof_of_possession" {
+		t.Errorf("unexpected BadRequest %v", badRequest)
+	}
+
+	// Errors not caused by a field have no BadRequest details
+	err = handleFulcioGRPCError(context.Background(), codes.Internal, ReasonCAError, errors.New("boom"), genericCAError)
+	for _, detail := range statusDetails(t, err) {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && (info.Reason != ReasonCAError || info.Metadata != nil) {
+			t.Errorf("unexpected ErrorInfo %v", info)
+		}
+		if _, ok := detail.(*errdetails.BadRequest); ok {
+			t.Errorf("unexpected BadRequest details")
+		}
+	}
+}
+
+func TestAPIWithWrongAudience(t *testing.T) {
+	emailSigner, emailIssuer := newOIDCIssuer(t)
+
+	cfg, err := config.Read([]byte(fmt.Sprintf(`{
+		"OIDCIssuers": {
+			%q: {
+				"IssuerURL": %q,
+				"ClientID": "sigstore",
+				"Type": "email"
+			}
+		}
+	}`, emailIssuer, emailIssuer)))
+	if err != nil {
+		t.Fatalf("config.Read() = %v", err)
+	}
+
+	tok, err := jwt.Signed(emailSigner).Claims(jwt.Claims{
+		Issuer:   emailIssuer,
+		IssuedAt: jwt.NewNumericDate(time.Now()),
+		Expiry:   jwt.NewNumericDate(time.Now().Add(30 * time.Minute)),
+		Subject:  "foo@example.com",
+		Audience: jwt.Audience{"other"},
+	}).Claims(customClaims{Email: "foo@example.com", EmailVerified: true}).Serialize()
+	if err != nil {
+		t.Fatalf("Serialize() = %v", err)
+	}
+
+	ctClient, eca := createCA(cfg, t)
+	server, conn := setupGRPCForTest(t, cfg, ctClient, eca)
+	defer func() {
+		server.Stop()
+		conn.Close()
+	}()
+
+	client := protobuf.NewCAClient(conn)
+	pubBytes, proof := generateKeyAndProof("foo@example.com", t)
+	_, err = client.CreateSigningCertificate(context.Background(), &protobuf.CreateSigningCertificateRequest{
+		Credentials: &protobuf.Credentials{
+			Credentials: &protobuf.Credentials_OidcIdentityToken{
+				OidcIdentityToken: tok,
+			},
+		},
+		Key: &protobuf.CreateSigningCertificateRequest_PublicKeyRequest{
+			PublicKeyRequest: &protobuf.PublicKeyRequest{
+				PublicKey: &protobuf.PublicKey{
+					Content: pubBytes,
+				},
+				ProofOfPossession: proof,
+			},
+		},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+	for _, detail := range statusDetails(t, err) {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.Reason != ReasonAudienceMismatch {
+			t.Errorf("expected reason %s, got %s", ReasonAudienceMismatch, info.Reason)
+		}
+	}
+}
+
+func statusDetails(t *testing.T, err error) []interface{} {
+	t.Helper()
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("%v is not a status", err)
+	}
+	return st.Details()
+}
@@ -0,0 +1,66 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package server
+
+import (
+	"context"
+
+	"github.com/sigstore/fulcio/pkg/config"
+	fulciogrpc "github.com/sigstore/fulcio/pkg/generated/protobuf"
+	"github.com/sigstore/fulcio/pkg/identity"
+)
+
+// ExplainIdentityToken reports how the token in the request is authenticated,
+// and which step failed if it is rejected, including the policy of the issuer.
+// The key policy, challenges and authorization webhook are not evaluated, as
+// they depend on the key of a certificate request. Unlike other RPCs, the
+// underlying errors are returned to the caller, since they only describe the
+// caller's own token and the public configuration of this instance.
+func (g *grpcaCAServer) ExplainIdentityToken(ctx context.Context, request *fulciogrpc.ExplainIdentityTokenRequest) (*fulciogrpc.IdentityTokenExplanation, error) {
+	token := requestToken(ctx, request.Credentials)
+	e := issuerPoolFromContext(ctx, g.IssuerPool).Explain(ctx, token)
+
+	explanation := &fulciogrpc.IdentityTokenExplanation{
+		Issuer:            e.Issuer,
+		ParseError:        errorString(e.ParseError),
+		MatchError:        errorString(e.MatchError),
+		VerificationError: errorString(e.VerificationError),
+		ClaimsError:       errorString(e.ClaimsError),
+	}
+	if cfg := config.FromContext(ctx); cfg != nil && e.MatchError == nil {
+		if iss, ok := cfg.GetIssuer(e.Issuer); ok {
+			explanation.IssuerType = string(iss.Type)
+		}
+	}
+	if e.Principal != nil {
+		explanation.PrincipalName = e.Principal.Name(ctx)
+
+		// The token has been verified, so its claims can be trusted
+		claims, _ := identity.ExtractClaims(token)
+		if cfg := config.FromContext(ctx); cfg != nil {
+			iss, _ := cfg.GetIssuer(e.Issuer)
+			explanation.PolicyError = errorString(iss.CheckPolicy(claims, explanation.PrincipalName))
+		}
+	}
+	return explanation, nil
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
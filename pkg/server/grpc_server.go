@@ -21,15 +21,23 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
-	ctclient "github.com/google/certificate-transparency-go/client"
+	ct "github.com/google/certificate-transparency-go"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	health "google.golang.org/grpc/health/grpc_health_v1"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/sigstore/fulcio/pkg/audit"
+	"github.com/sigstore/fulcio/pkg/authz"
 	certauth "github.com/sigstore/fulcio/pkg/ca"
+	"github.com/sigstore/fulcio/pkg/certstore"
 	"github.com/sigstore/fulcio/pkg/challenges"
 	"github.com/sigstore/fulcio/pkg/config"
 	"github.com/sigstore/fulcio/pkg/ctl"
@@ -44,32 +52,246 @@ type GRPCCAServer interface {
 	health.HealthServer
 }
 
-func NewGRPCCAServer(ct *ctclient.LogClient, ca certauth.CertificateAuthority, ip identity.IssuerPool) GRPCCAServer {
-	return &grpcaCAServer{
-		ct:         ct,
+// GRPCCAServerOption configures optional behavior of the server returned by
+// NewGRPCCAServer.
+type GRPCCAServerOption func(*grpcaCAServer)
+
+// WithHealthChecker reports the status of the server's dependencies through
+// the gRPC health service. Without it the server always reports SERVING.
+func WithHealthChecker(hc *HealthChecker) GRPCCAServerOption {
+	return func(g *grpcaCAServer) {
+		g.health = hc
+	}
+}
+
+// WithAuditLogger records an audit event for every certificate request.
+func WithAuditLogger(l *audit.Logger) GRPCCAServerOption {
+	return func(g *grpcaCAServer) {
+		g.audit = l
+	}
+}
+
+// WithCTLogs submits certificates to every log in logs. Without it,
+// certificates are not submitted to a CT log.
+func WithCTLogs(logs *ctl.Logs) GRPCCAServerOption {
+	return func(g *grpcaCAServer) {
+		g.logs = logs
+	}
+}
+
+// WithCertificateStore records every issued certificate in store.
+func WithCertificateStore(store certstore.Store) GRPCCAServerOption {
+	return func(g *grpcaCAServer) {
+		g.store = store
+	}
+}
+
+// WithCertificateLookup serves the GetCertificate and SearchCertificates RPCs
+// from the certificate store. They are not authenticated, so anyone who can
+// reach the server can list the identities that certificates were issued to.
+func WithCertificateLookup() GRPCCAServerOption {
+	return func(g *grpcaCAServer) {
+		g.lookup = true
+	}
+}
+
+func NewGRPCCAServer(ca certauth.CertificateAuthority, ip identity.IssuerPool, opts ...GRPCCAServerOption) GRPCCAServer {
+	g := &grpcaCAServer{
 		ca:         ca,
 		IssuerPool: ip,
 	}
+	for _, o := range opts {
+		o(g)
+	}
+	return g
 }
 
 const (
 	MetadataOIDCTokenKey = "oidcidentitytoken"
+
+	// maxKeysPerRequest is the maximum number of keys accepted by CreateSigningCertificates
+	maxKeysPerRequest = 100
+	// maxConcurrentIssuance bounds how many certificates from a single
+	// CreateSigningCertificates request are issued concurrently
+	maxConcurrentIssuance = 10
+	// maxSearchPageSize is the maximum number of certificates returned by SearchCertificates
+	maxSearchPageSize = 100
 )
 
 type grpcaCAServer struct {
 	fulciogrpc.UnimplementedCAServer
-	ct *ctclient.LogClient
 	ca certauth.CertificateAuthority
 	identity.IssuerPool
+	health *HealthChecker
+	audit  *audit.Logger
+	store  certstore.Store
+	// lookup is set if the certificate store can be queried
+	lookup bool
+	// clientCerts is nil unless client certificate credentials are accepted
+	clientCerts *ClientCertificateVerifier
+	// nonces is nil unless challenges are issued
+	nonces *challenges.NonceIssuer
+	// authorizer is nil unless issuance is authorized by a webhook
+	authorizer *authz.Webhook
+	// logs is nil unless certificates are submitted to CT logs
+	logs *ctl.Logs
 }
 
-func (g *grpcaCAServer) CreateSigningCertificate(ctx context.Context, request *fulciogrpc.CreateSigningCertificateRequest) (*fulciogrpc.SigningCertificate, error) {
-	logger := log.ContextLogger(ctx)
+func (g *grpcaCAServer) CreateSigningCertificate(ctx context.Context, request *fulciogrpc.CreateSigningCertificateRequest) (result *fulciogrpc.SigningCertificate, err error) {
+	ctx = withRequestIssuer(ctx, requestToken(ctx, request.Credentials))
+	event := &audit.Event{}
+	defer func() {
+		g.recordAudit(ctx, event, err)
+	}()
+
+	principal, issuerURL, err := g.verifyCredentials(ctx, request.Credentials)
+	if err != nil {
+		return nil, err
+	}
+	event = newAuditEvent(ctx, principal, issuerURL)
+	ctx, err = withValidity(ctx, issuerURL, request.RequestedValidity)
+	if err != nil {
+		return nil, err
+	}
+
+	consume := func() error { return g.consumeToken(ctx, request.Credentials) }
+	return g.createSigningCertificate(ctx, principal, issuerURL, requestClaims(ctx, request.Credentials), request.GetCertificateSigningRequest(), request.GetPublicKeyRequest(), consume, event)
+}
+
+func (g *grpcaCAServer) CreateSigningCertificates(ctx context.Context, request *fulciogrpc.CreateSigningCertificatesRequest) (*fulciogrpc.CreateSigningCertificatesResponse, error) {
+	ctx = withRequestIssuer(ctx, requestToken(ctx, request.Credentials))
+	if len(request.Keys) == 0 {
+		err := handleFulcioGRPCError(ctx, codes.InvalidArgument, ReasonInvalidRequest, withField("keys", errors.New("no keys in request")), noKeysRequested)
+		g.recordAudit(ctx, &audit.Event{}, err)
+		return nil, err
+	}
+	if len(request.Keys) > maxKeysPerRequest {
+		err := fmt.Errorf("%d keys in request, at most %d allowed", len(request.Keys), maxKeysPerRequest)
+		err = handleFulcioGRPCError(ctx, codes.InvalidArgument, ReasonInvalidRequest, withField("keys", err), tooManyKeysRequested)
+		g.recordAudit(ctx, &audit.Event{}, err)
+		return nil, err
+	}
+
+	// The token is verified once and the principal is shared by every certificate
+	principal, issuerURL, err := g.verifyCredentials(ctx, request.Credentials)
+	if err != nil {
+		g.recordAudit(ctx, &audit.Event{}, err)
+		return nil, err
+	}
+	ctx, err = withValidity(ctx, issuerURL, request.RequestedValidity)
+	if err != nil {
+		g.recordAudit(ctx, newAuditEvent(ctx, principal, issuerURL), err)
+		return nil, err
+	}
+
+	claims := requestClaims(ctx, request.Credentials)
+	// The token is used up by the first key to pass validation, and the
+	// outcome is shared by the others
+	consume := sync.OnceValue(func() error { return g.consumeToken(ctx, request.Credentials) })
+	results := make([]*fulciogrpc.SigningCertificateResult, len(request.Keys))
+	sem := make(chan struct{}, maxConcurrentIssuance)
+	var wg sync.WaitGroup
+	for i, key := range request.Keys {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			event := newAuditEvent(ctx, principal, issuerURL)
+			cert, err := g.createSigningCertificate(ctx, principal, issuerURL, claims, key.GetCertificateSigningRequest(), key.GetPublicKeyRequest(), consume, event)
+			g.recordAudit(ctx, event, err)
+			if err != nil {
+				results[i] = &fulciogrpc.SigningCertificateResult{
+					Result: &fulciogrpc.SigningCertificateResult_Error{Error: status.Convert(err).Proto()},
+				}
+				return
+			}
+			results[i] = &fulciogrpc.SigningCertificateResult{
+				Result: &fulciogrpc.SigningCertificateResult_SigningCertificate{SigningCertificate: cert},
+			}
+		}()
+	}
+	wg.Wait()
+
+	return &fulciogrpc.CreateSigningCertificatesResponse{Results: results}, nil
+}
+
+// consumeToken uses up the OIDC token from the credentials, or from the
+// request metadata if the credentials are empty, if its issuer only accepts
+// each token once. Client certificates are never used up.
+func (g *grpcaCAServer) consumeToken(ctx context.Context, credentials *fulciogrpc.Credentials) error {
+	if credentials.GetClientCertificate() != nil {
+		return nil
+	}
+	err := issuerPoolFromContext(ctx, g.IssuerPool).Consume(ctx, requestToken(ctx, credentials))
+	if errors.Is(err, identity.ErrTokenReplayed) {
+		return handleFulcioGRPCError(ctx, codes.InvalidArgument, ReasonTokenReplayed, withField("credentials.oidc_identity_token", err), identityTokenReplayed)
+	}
+	if err != nil {
+		return handleFulcioGRPCError(ctx, codes.Internal, ReasonInternal, err, invalidIdentityToken)
+	}
+	return nil
+}
+
+// verifyCredentials verifies the TLS client certificate if the credentials
+// select it, or else the OIDC token from the credentials, or from the request
+// metadata if the credentials are empty, checks the policy of the issuer, and
+// returns the principal and issuer URL of the caller. Tokens of issuers that
+// only accept each token once are not used up; see consumeToken.
+func (g *grpcaCAServer) verifyCredentials(ctx context.Context, credentials *fulciogrpc.Credentials) (identity.Principal, string, error) {
+	if credentials.GetClientCertificate() != nil {
+		principal, issuerURL, err := g.authenticateClientCertificate(ctx)
+		if err != nil {
+			return nil, "", err
+		}
+		if err := checkPolicy(ctx, principal, issuerURL, nil); err != nil {
+			return nil, "", err
+		}
+		return principal, issuerURL, nil
+	}
+	token := requestToken(ctx, credentials)
+
+	// Authenticate OIDC ID token by checking signature, unless an interceptor already has
+	principal, ok := authenticatedFromContext(ctx, token)
+	if !ok {
+		var err error
+		principal, err = issuerPoolFromContext(ctx, g.IssuerPool).Verify(ctx, token)
+		if err != nil {
+			return nil, "", handleFulcioGRPCError(ctx, codes.InvalidArgument, tokenErrorReason(err), withField("credentials.oidc_identity_token", err), invalidIdentityToken)
+		}
+	}
+	// The token was parsed successfully above, so extracting the issuer can't fail
+	issuerURL, _ := identity.ExtractIssuerURL(token)
+	claims, _ := identity.ExtractClaims(token)
+	if err := checkPolicy(ctx, principal, issuerURL, claims); err != nil {
+		return nil, "", err
+	}
+	return principal, issuerURL, nil
+}
+
+// authenticateClientCertificate verifies the TLS client certificate of the
+// connection and returns its principal and issuer.
+func (g *grpcaCAServer) authenticateClientCertificate(ctx context.Context) (identity.Principal, string, error) {
+	if g.clientCerts == nil {
+		err := errors.New("client certificate credentials are not enabled")
+		return nil, "", handleFulcioGRPCError(ctx, codes.InvalidArgument, ReasonInvalidClientCertificate, withField("credentials.client_certificate", err), invalidClientCertificate)
+	}
+	principal, issuerURL, err := g.clientCerts.Authenticate(ctx)
+	if err != nil {
+		return nil, "", handleFulcioGRPCError(ctx, codes.InvalidArgument, ReasonInvalidClientCertificate, withField("credentials.client_certificate", err), invalidClientCertificate)
+	}
+	return principal, issuerURL, nil
+}
 
+// requestToken returns the OIDC token from the credentials, or from the
+// request metadata if the credentials are empty.
+func requestToken(ctx context.Context, credentials *fulciogrpc.Credentials) string {
 	// OIDC token either is passed in gRPC field or was extracted from HTTP headers
 	token := ""
-	if request.Credentials != nil {
-		token = request.Credentials.GetOidcIdentityToken()
+	if credentials != nil {
+		token = credentials.GetOidcIdentityToken()
 	}
 
 	if token == "" {
@@ -80,31 +302,111 @@ func (g *grpcaCAServer) CreateSigningCertificate(ctx context.Context, request *f
 			}
 		}
 	}
+	return token
+}
+
+// withValidity returns a context that sets the validity of issued certificates
+// to the requested lifetime, clamped to the bounds configured for the issuer.
+func withValidity(ctx context.Context, issuerURL string, requested *durationpb.Duration) (context.Context, error) {
+	var lifetime time.Duration
+	if requested != nil {
+		if err := requested.CheckValid(); err != nil {
+			return nil, handleFulcioGRPCError(ctx, codes.InvalidArgument, ReasonInvalidRequest, withField("requested_validity", err), invalidRequestedValidity)
+		}
+		lifetime = requested.AsDuration()
+		if lifetime < 0 {
+			return nil, handleFulcioGRPCError(ctx, codes.InvalidArgument, ReasonInvalidRequest, withField("requested_validity", fmt.Errorf("negative requested validity %v", lifetime)), invalidRequestedValidity)
+		}
+	}
 
-	// Authenticate OIDC ID token by checking signature
-	principal, err := g.IssuerPool.Authenticate(ctx, token)
+	var iss config.OIDCIssuer
+	if cfg := config.FromContext(ctx); cfg != nil {
+		iss, _ = cfg.GetIssuer(issuerURL)
+	}
+	return certauth.WithValidity(ctx, certauth.Validity{
+		Lifetime: iss.CertificateLifetime(lifetime),
+		Backdate: time.Duration(iss.CertificateBackdate),
+	}), nil
+}
+
+// newAuditEvent returns an audit event describing an authenticated requester.
+func newAuditEvent(ctx context.Context, principal identity.Principal, issuerURL string) *audit.Event {
+	event := &audit.Event{
+		Issuer:    issuerURL,
+		Principal: principal.Name(ctx),
+	}
+	if cfg := config.FromContext(ctx); cfg != nil {
+		if iss, ok := cfg.GetIssuer(issuerURL); ok {
+			event.IssuerType = string(iss.Type)
+		}
+	}
+	return event
+}
+
+// recordAudit records the outcome of the certificate request described by
+// event.
+func (g *grpcaCAServer) recordAudit(ctx context.Context, event *audit.Event, err error) {
+	setAuditOutcome(event, err)
+	g.audit.Record(ctx, event)
+}
+
+// setAuditOutcome sets the outcome of event to the result of a certificate
+// request. The reason for a failure is the reason code of its ErrorInfo
+// details, and the message returned to the client is recorded separately.
+func setAuditOutcome(event *audit.Event, err error) {
 	if err != nil {
-		return nil, handleFulcioGRPCError(ctx, codes.InvalidArgument, err, invalidIdentityToken)
+		s := status.Convert(err)
+		event.Outcome = audit.OutcomeFailure
+		event.Code = s.Code().String()
+		event.Message = s.Message()
+		for _, detail := range s.Details() {
+			if info, ok := detail.(*errdetails.ErrorInfo); ok {
+				event.Reason = info.Reason
+			}
+		}
+	} else {
+		event.Outcome = audit.OutcomeSuccess
 	}
+}
 
-	var publicKey crypto.PublicKey
+// verifyPublicKey returns the public key in either csrBytes or pkr, after
+// checking that it is secure and that the caller possesses its private key,
+// and the challenge signed as proof of possession if any. The challenge is
+// verified but not used up.
+func (g *grpcaCAServer) verifyPublicKey(ctx context.Context, principal identity.Principal, issuerURL string, csrBytes []byte, pkr *fulciogrpc.PublicKeyRequest) (crypto.PublicKey, string, error) {
+	var (
+		publicKey crypto.PublicKey
+		challenge string
+	)
 	// Verify caller is in possession of their private key and extract
 	// public key from request.
-	if len(request.GetCertificateSigningRequest()) > 0 {
+	if len(csrBytes) > 0 {
 		// Option 1: Verify CSR
-		csr, err := cryptoutils.ParseCSR(request.GetCertificateSigningRequest())
+		csr, err := cryptoutils.ParseCSR(csrBytes)
 		if err != nil {
-			return nil, handleFulcioGRPCError(ctx, codes.InvalidArgument, err, invalidCSR)
+			return nil, "", handleFulcioGRPCError(ctx, codes.InvalidArgument, ReasonInvalidCSR, withField("certificate_signing_request", err), invalidCSR)
 		}
 
 		// Parse public key and check for weak key parameters
 		publicKey = csr.PublicKey
 		if err := cryptoutils.ValidatePubKey(publicKey); err != nil {
-			return nil, handleFulcioGRPCError(ctx, codes.InvalidArgument, err, insecurePublicKey)
+			return nil, "", handleFulcioGRPCError(ctx, codes.InvalidArgument, ReasonWeakKey, withField("certificate_signing_request", err), insecurePublicKey)
+		}
+		if err := checkKeyPolicy(ctx, issuerURL, publicKey, "certificate_signing_request"); err != nil {
+			return nil, "", err
 		}
 
 		if err := csr.CheckSignature(); err != nil {
-			return nil, handleFulcioGRPCError(ctx, codes.InvalidArgument, err, invalidSignature)
+			return nil, "", handleFulcioGRPCError(ctx, codes.InvalidArgument, ReasonPOPSignatureInvalid, withField("certificate_signing_request", err), invalidSignature)
+		}
+
+		// A CSR is its own proof of possession, but can only be bound to a
+		// challenge through its subject
+		if requireChallenge(ctx, issuerURL) {
+			if _, err := g.proofSubject(ctx, principal, issuerURL, csr.Subject.CommonName, "certificate_signing_request.subject"); err != nil {
+				return nil, "", err
+			}
+			challenge = csr.Subject.CommonName
 		}
 	} else {
 		// Option 2: Check the signature for proof of possession of a private key
@@ -113,59 +415,173 @@ func (g *grpcaCAServer) CreateSigningCertificate(ctx context.Context, request *f
 			proofOfPossession []byte
 			err               error
 		)
-		if request.GetPublicKeyRequest() != nil {
-			if request.GetPublicKeyRequest().PublicKey != nil {
-				pubKeyContent = request.GetPublicKeyRequest().PublicKey.Content
+		if pkr != nil {
+			if pkr.PublicKey != nil {
+				pubKeyContent = pkr.PublicKey.Content
 			}
-			proofOfPossession = request.GetPublicKeyRequest().ProofOfPossession
+			proofOfPossession = pkr.ProofOfPossession
+			challenge = pkr.Challenge
 		}
 
 		// Parse public key and check for weak parameters
 		publicKey, err = challenges.ParsePublicKey(pubKeyContent)
 		if err != nil {
-			return nil, handleFulcioGRPCError(ctx, codes.InvalidArgument, err, invalidPublicKey)
+			return nil, "", handleFulcioGRPCError(ctx, codes.InvalidArgument, ReasonInvalidPublicKey, withField("public_key_request.public_key", err), invalidPublicKey)
 		}
 		if err := cryptoutils.ValidatePubKey(publicKey); err != nil {
-			return nil, handleFulcioGRPCError(ctx, codes.InvalidArgument, err, insecurePublicKey)
+			return nil, "", handleFulcioGRPCError(ctx, codes.InvalidArgument, ReasonWeakKey, withField("public_key_request.public_key", err), insecurePublicKey)
+		}
+		if err := checkKeyPolicy(ctx, issuerURL, publicKey, "public_key_request.public_key"); err != nil {
+			return nil, "", err
 		}
 
 		// Check proof of possession signature
-		if err := challenges.CheckSignature(publicKey, proofOfPossession, principal.Name(ctx)); err != nil {
-			return nil, handleFulcioGRPCError(ctx, codes.InvalidArgument, err, invalidSignature)
+		subject, err := g.proofSubject(ctx, principal, issuerURL, challenge, "public_key_request.challenge")
+		if err != nil {
+			return nil, "", err
+		}
+		alg, hash, err := proofAlgorithm(pkr.GetPublicKey())
+		if err != nil {
+			return nil, "", handleFulcioGRPCError(ctx, codes.InvalidArgument, ReasonUnsupportedAlgorithm, err, unsupportedSignatureAlgorithm)
+		}
+		if err := challenges.CheckSignatureWithAlgorithm(publicKey, proofOfPossession, subject, alg, hash); err != nil {
+			return nil, "", handleFulcioGRPCError(ctx, codes.InvalidArgument, ReasonPOPSignatureInvalid, withField("public_key_request.proof_of_possession", err), invalidSignature)
+		}
+	}
+
+	return publicKey, challenge, nil
+}
+
+// checkKeyPolicy checks that the key policy of the issuer allows publicKey.
+// field is the request field holding the key. Without a configuration the
+// policy can't be known, so every key is rejected.
+func checkKeyPolicy(ctx context.Context, issuerURL string, publicKey crypto.PublicKey, field string) error {
+	cfg := config.FromContext(ctx)
+	if cfg == nil {
+		return handleFulcioGRPCError(ctx, codes.Internal, ReasonInternal, errors.New("configuration not loaded"), loadingFulcioConfigurationError)
+	}
+	iss, _ := cfg.GetIssuer(issuerURL)
+	if err := iss.KeyPolicy.Check(publicKey); err != nil {
+		return handleFulcioGRPCError(ctx, codes.InvalidArgument, ReasonKeyPolicyViolation, withField(field, err), keyPolicyViolation)
+	}
+	return nil
+}
+
+// checkPolicy checks that the policy of the issuer allows the principal,
+// authenticated either by a client certificate or by a token with the given
+// claims. Without a configuration the policy can't be known, so every request
+// is denied.
+func checkPolicy(ctx context.Context, principal identity.Principal, issuerURL string, claims map[string]any) error {
+	cfg := config.FromContext(ctx)
+	if cfg == nil {
+		return handleFulcioGRPCError(ctx, codes.Internal, ReasonInternal, errors.New("configuration not loaded"), loadingFulcioConfigurationError)
+	}
+	if iss, ok := cfg.ClientCertificateIssuers[issuerURL]; ok {
+		if err := iss.CheckPolicy(principal.Name(ctx)); err != nil {
+			return handleFulcioGRPCError(ctx, codes.PermissionDenied, ReasonPolicyDenied, withField("credentials.client_certificate", err), policyDenied)
 		}
+		return nil
+	}
+	iss, _ := cfg.GetIssuer(issuerURL)
+	if err := iss.CheckPolicy(claims, principal.Name(ctx)); err != nil {
+		return handleFulcioGRPCError(ctx, codes.PermissionDenied, ReasonPolicyDenied, withField("credentials.oidc_identity_token", err), policyDenied)
+	}
+	return nil
+}
+
+// proofAlgorithm returns the signature scheme and hash function of the proof
+// of possession for key.
+func proofAlgorithm(key *fulciogrpc.PublicKey) (challenges.SignatureAlgorithm, crypto.Hash, error) {
+	var alg challenges.SignatureAlgorithm
+	switch key.GetAlgorithm() {
+	case fulciogrpc.PublicKeyAlgorithm_PUBLIC_KEY_ALGORITHM_UNSPECIFIED:
+		alg = challenges.SignatureAlgorithmDefault
+	case fulciogrpc.PublicKeyAlgorithm_RSA_PSS:
+		alg = challenges.SignatureAlgorithmRSAPSS
+	case fulciogrpc.PublicKeyAlgorithm_ECDSA:
+		alg = challenges.SignatureAlgorithmECDSA
+	case fulciogrpc.PublicKeyAlgorithm_ED25519:
+		alg = challenges.SignatureAlgorithmED25519
+	default:
+		return 0, 0, withField("public_key_request.public_key.algorithm", fmt.Errorf("unknown public key algorithm %v", key.GetAlgorithm()))
 	}
 
-	var csc *certauth.CodeSigningCertificate
-	var sctBytes []byte
+	var hash crypto.Hash
+	switch key.GetHashAlgorithm() {
+	case fulciogrpc.HashAlgorithm_HASH_ALGORITHM_UNSPECIFIED, fulciogrpc.HashAlgorithm_SHA2_256:
+		hash = crypto.SHA256
+	case fulciogrpc.HashAlgorithm_SHA2_384:
+		hash = crypto.SHA384
+	case fulciogrpc.HashAlgorithm_SHA2_512:
+		hash = crypto.SHA512
+	default:
+		return 0, 0, withField("public_key_request.public_key.hash_algorithm", fmt.Errorf("unknown hash algorithm %v", key.GetHashAlgorithm()))
+	}
+	return alg, hash, nil
+}
+
+// createSigningCertificate issues a certificate for an authenticated principal,
+// with the claims of its token if any, and the key in either csrBytes or pkr.
+// consume uses up the token once the request has passed every check, just
+// before signing. Details of the issued certificate are added to event.
+func (g *grpcaCAServer) createSigningCertificate(ctx context.Context, principal identity.Principal, issuerURL string, claims map[string]any, csrBytes []byte, pkr *fulciogrpc.PublicKeyRequest, consume func() error, event *audit.Event) (*fulciogrpc.SigningCertificate, error) {
+	logger := log.ContextLogger(ctx)
+
+	publicKey, challenge, err := g.verifyPublicKey(ctx, principal, issuerURL, csrBytes, pkr)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.authorize(ctx, principal, issuerURL, claims, publicKey); err != nil {
+		return nil, err
+	}
+	// Challenges are only used up once the request is allowed
+	if err := g.consumeChallenge(ctx, challenge, len(csrBytes) > 0); err != nil {
+		return nil, err
+	}
+	if err := consume(); err != nil {
+		return nil, err
+	}
+
+	var (
+		csc      *certauth.CodeSigningCertificate
+		scts     []*ct.SignedCertificateTimestamp
+		sctBytes [][]byte
+	)
 	result := &fulciogrpc.SigningCertificate{}
-	// For CAs that do not support embedded SCTs or if the CT log is not configured
-	if sctCa, ok := g.ca.(certauth.EmbeddedSCTCA); !ok || g.ct == nil {
+	// For CAs that do not support embedded SCTs or if no CT log is configured
+	if sctCa, ok := g.ca.(certauth.EmbeddedSCTCA); !ok || g.logs == nil {
 		// currently configured CA doesn't support pre-certificate flow required to embed SCT in final certificate
 		csc, err = g.ca.CreateCertificate(ctx, principal, publicKey)
 		if err != nil {
 			// if the error was due to invalid input in the request, return HTTP 400
 			if _, ok := err.(certauth.ValidationError); ok {
-				return nil, handleFulcioGRPCError(ctx, codes.InvalidArgument, err, err.Error())
+				return nil, handleFulcioGRPCError(ctx, codes.InvalidArgument, ReasonInvalidClaims, err, err.Error())
 			}
 			err = fmt.Errorf("Error creating certificate: %w", err)
 			// otherwise return a 500 error to reflect that it is a transient server issue that the client can't resolve
-			return nil, handleFulcioGRPCError(ctx, codes.Internal, err, genericCAError)
+			return nil, handleFulcioGRPCError(ctx, codes.Internal, ReasonCAError, err, genericCAError)
 		}
 
 		// Submit to CTL
-		if g.ct != nil {
-			sct, err := g.ct.AddChain(ctx, ctl.BuildCTChain(csc.FinalCertificate, csc.FinalChain))
-			if err != nil {
-				return nil, handleFulcioGRPCError(ctx, codes.Internal, err, failedToEnterCertInCTL)
+		if g.logs != nil {
+			scts, err = g.logs.AddChain(ctx, ctl.BuildCTChain(csc.FinalCertificate, csc.FinalChain))
+			if errors.Is(err, ctl.ErrSCTVerification) {
+				return nil, handleFulcioGRPCError(ctx, codes.Internal, ReasonSCTVerificationFailed, err, failedToVerifySCT)
 			}
-			// convert to AddChainResponse because Cosign expects this struct.
-			addChainResp, err := ctl.ToAddChainResponse(sct)
 			if err != nil {
-				return nil, handleFulcioGRPCError(ctx, codes.Internal, err, failedToMarshalSCT)
+				return nil, handleFulcioGRPCError(ctx, codes.Internal, ReasonCTSubmissionFailed, err, failedToEnterCertInCTL)
 			}
-			sctBytes, err = json.Marshal(addChainResp)
-			if err != nil {
-				return nil, handleFulcioGRPCError(ctx, codes.Internal, err, failedToMarshalSCT)
+			for _, sct := range scts {
+				// convert to AddChainResponse because Cosign expects this struct.
+				addChainResp, err := ctl.ToAddChainResponse(sct)
+				if err != nil {
+					return nil, handleFulcioGRPCError(ctx, codes.Internal, ReasonInternal, err, failedToMarshalSCT)
+				}
+				b, err := json.Marshal(addChainResp)
+				if err != nil {
+					return nil, handleFulcioGRPCError(ctx, codes.Internal, ReasonInternal, err, failedToMarshalSCT)
+				}
+				sctBytes = append(sctBytes, b)
 			}
 		} else {
 			logger.Info("Skipping CT log upload.")
@@ -173,12 +589,12 @@ func (g *grpcaCAServer) CreateSigningCertificate(ctx context.Context, request *f
 
 		finalPEM, err := csc.CertPEM()
 		if err != nil {
-			return nil, handleFulcioGRPCError(ctx, codes.Internal, err, failedToMarshalCert)
+			return nil, handleFulcioGRPCError(ctx, codes.Internal, ReasonInternal, err, failedToMarshalCert)
 		}
 
 		finalChainPEM, err := csc.ChainPEM()
 		if err != nil {
-			return nil, handleFulcioGRPCError(ctx, codes.Internal, err, failedToMarshalCert)
+			return nil, handleFulcioGRPCError(ctx, codes.Internal, ReasonInternal, err, failedToMarshalCert)
 		}
 
 		result.Certificate = &fulciogrpc.SigningCertificate_SignedCertificateDetachedSct{
@@ -189,38 +605,46 @@ func (g *grpcaCAServer) CreateSigningCertificate(ctx context.Context, request *f
 			},
 		}
 		if len(sctBytes) > 0 {
-			result.GetSignedCertificateDetachedSct().SignedCertificateTimestamp = sctBytes
+			result.GetSignedCertificateDetachedSct().SignedCertificateTimestamp = sctBytes[0]
+			result.GetSignedCertificateDetachedSct().SignedCertificateTimestamps = sctBytes
 		}
 	} else {
 		precert, err := sctCa.CreatePrecertificate(ctx, principal, publicKey)
 		if err != nil {
 			// if the error was due to invalid input in the request, return HTTP 400
 			if _, ok := err.(certauth.ValidationError); ok {
-				return nil, handleFulcioGRPCError(ctx, codes.InvalidArgument, err, err.Error())
+				return nil, handleFulcioGRPCError(ctx, codes.InvalidArgument, ReasonInvalidClaims, err, err.Error())
 			}
 			err = fmt.Errorf("Error creating a pre-certificate and chain: %w", err)
 			// otherwise return a 500 error to reflect that it is a transient server issue that the client can't resolve
-			return nil, handleFulcioGRPCError(ctx, codes.Internal, err, genericCAError)
+			return nil, handleFulcioGRPCError(ctx, codes.Internal, ReasonCAError, err, genericCAError)
+		}
+		// submit precertificate and chain to CT logs
+		scts, err = g.logs.AddPreChain(ctx, ctl.BuildCTChain(precert.PreCert, precert.CertChain))
+		if errors.Is(err, ctl.ErrSCTVerification) {
+			return nil, handleFulcioGRPCError(ctx, codes.Internal, ReasonSCTVerificationFailed, err, failedToVerifySCT)
 		}
-		// submit precertificate and chain to CT log
-		sct, err := g.ct.AddPreChain(ctx, ctl.BuildCTChain(precert.PreCert, precert.CertChain))
 		if err != nil {
-			return nil, handleFulcioGRPCError(ctx, codes.Internal, err, failedToEnterCertInCTL)
+			return nil, handleFulcioGRPCError(ctx, codes.Internal, ReasonCTSubmissionFailed, err, failedToEnterCertInCTL)
 		}
-		csc, err = sctCa.IssueFinalCertificate(ctx, precert, sct)
+		csc, err = sctCa.IssueFinalCertificate(ctx, precert, scts...)
 		if err != nil {
 			err = fmt.Errorf("Error issuing final certificate using the pre-certificate with CA backend: %w", err)
-			return nil, handleFulcioGRPCError(ctx, codes.Internal, err, genericCAError)
+			return nil, handleFulcioGRPCError(ctx, codes.Internal, ReasonCAError, err, genericCAError)
+		}
+		// check that the embedded SCTs verify against the precertificate
+		if err := g.logs.VerifyEmbeddedSCTs(ctl.BuildCTChain(csc.FinalCertificate, csc.FinalChain), scts); err != nil {
+			return nil, handleFulcioGRPCError(ctx, codes.Internal, ReasonSCTVerificationFailed, err, failedToVerifySCT)
 		}
 
 		finalPEM, err := csc.CertPEM()
 		if err != nil {
-			return nil, handleFulcioGRPCError(ctx, codes.Internal, err, failedToMarshalCert)
+			return nil, handleFulcioGRPCError(ctx, codes.Internal, ReasonInternal, err, failedToMarshalCert)
 		}
 
 		finalChainPEM, err := csc.ChainPEM()
 		if err != nil {
-			return nil, handleFulcioGRPCError(ctx, codes.Internal, err, failedToMarshalCert)
+			return nil, handleFulcioGRPCError(ctx, codes.Internal, ReasonInternal, err, failedToMarshalCert)
 		}
 
 		result.Certificate = &fulciogrpc.SigningCertificate_SignedCertificateEmbeddedSct{
@@ -233,14 +657,48 @@ func (g *grpcaCAServer) CreateSigningCertificate(ctx context.Context, request *f
 	}
 
 	metricNewEntries.Inc()
+	event.SetCertificate(csc.FinalCertificate)
+	event.SetSCTs(scts)
+	g.storeCertificate(ctx, csc, event)
 
 	return result, nil
 }
 
+// storeCertificate records an issued certificate in the certificate store, if
+// one is configured. The certificate has already been issued and logged, so a
+// failure to store it is logged rather than failing the request.
+func (g *grpcaCAServer) storeCertificate(ctx context.Context, csc *certauth.CodeSigningCertificate, event *audit.Event) {
+	if g.store == nil {
+		return
+	}
+	record := &certstore.Record{
+		Serial:                  csc.FinalCertificate.SerialNumber.String(),
+		Issuer:                  event.Issuer,
+		Identity:                event.Principal,
+		SubjectAlternativeNames: event.SubjectAlternativeNames,
+		NotBefore:               csc.FinalCertificate.NotBefore,
+		NotAfter:                csc.FinalCertificate.NotAfter,
+		IssuedAt:                time.Now(),
+	}
+	finalPEM, err := csc.CertPEM()
+	if err == nil {
+		var finalChainPEM []string
+		finalChainPEM, err = csc.ChainPEM()
+		record.Chain = append([]string{finalPEM}, finalChainPEM...)
+	}
+	if err == nil {
+		err = g.store.Put(ctx, record)
+	}
+	if err != nil {
+		metricCertificateStoreErrors.Inc()
+		log.ContextLogger(ctx).Errorw("error storing issued certificate", "serial", record.Serial, "error", err)
+	}
+}
+
 func (g *grpcaCAServer) GetTrustBundle(ctx context.Context, _ *fulciogrpc.GetTrustBundleRequest) (*fulciogrpc.TrustBundle, error) {
 	trustBundle, err := g.ca.TrustBundle(ctx)
 	if err != nil {
-		return nil, handleFulcioGRPCError(ctx, codes.Internal, err, retrieveTrustBundleCAError)
+		return nil, handleFulcioGRPCError(ctx, codes.Internal, ReasonCAError, err, retrieveTrustBundleCAError)
 	}
 
 	resp := &fulciogrpc.TrustBundle{
@@ -252,7 +710,7 @@ func (g *grpcaCAServer) GetTrustBundle(ctx context.Context, _ *fulciogrpc.GetTru
 		for _, cert := range chain {
 			certPEM, err := cryptoutils.MarshalCertificateToPEM(cert)
 			if err != nil {
-				return nil, handleFulcioGRPCError(ctx, codes.Internal, err, marshalingCertificateChainBundleCAError)
+				return nil, handleFulcioGRPCError(ctx, codes.Internal, ReasonInternal, err, marshalingCertificateChainBundleCAError)
 			}
 			certChain.Certificates = append(certChain.Certificates, string(certPEM))
 		}
@@ -265,18 +723,126 @@ func (g *grpcaCAServer) GetConfiguration(ctx context.Context, _ *fulciogrpc.GetC
 	cfg := config.FromContext(ctx)
 	if cfg == nil {
 		err := errors.New("configuration not loaded")
-		return nil, handleFulcioGRPCError(ctx, codes.Internal, err, loadingFulcioConfigurationError)
+		return nil, handleFulcioGRPCError(ctx, codes.Internal, ReasonInternal, err, loadingFulcioConfigurationError)
 	}
 
+	issuers := cfg.ToIssuers()
+	sctDelivery := g.sctDelivery()
+	for _, iss := range issuers {
+		iss.CertificateProfile.SctDelivery = sctDelivery
+	}
 	return &fulciogrpc.Configuration{
-		Issuers: cfg.ToIssuers(),
+		Issuers: issuers,
 	}, nil
 }
 
-func (g *grpcaCAServer) Check(_ context.Context, _ *health.HealthCheckRequest) (*health.HealthCheckResponse, error) {
-	return &health.HealthCheckResponse{Status: health.HealthCheckResponse_SERVING}, nil
+// sctDelivery returns how SCTs are delivered for issued certificates, which
+// matches the choice made by createSigningCertificate.
+func (g *grpcaCAServer) sctDelivery() fulciogrpc.SCTDelivery {
+	if g.logs == nil {
+		return fulciogrpc.SCTDelivery_SCT_DELIVERY_NONE
+	}
+	if _, ok := g.ca.(certauth.EmbeddedSCTCA); ok {
+		return fulciogrpc.SCTDelivery_SCT_DELIVERY_EMBEDDED
+	}
+	return fulciogrpc.SCTDelivery_SCT_DELIVERY_DETACHED
+}
+
+func (g *grpcaCAServer) GetCertificate(ctx context.Context, request *fulciogrpc.GetCertificateRequest) (*fulciogrpc.IssuedCertificate, error) {
+	if err := g.checkCertificateLookup(ctx); err != nil {
+		return nil, err
+	}
+	record, err := g.store.Get(ctx, request.SerialNumber)
+	if errors.Is(err, certstore.ErrNotFound) {
+		return nil, handleFulcioGRPCError(ctx, codes.NotFound, ReasonCertificateNotFound, err, certificateNotFound)
+	}
+	if err != nil {
+		return nil, handleFulcioGRPCError(ctx, codes.Internal, ReasonCertificateStoreError, err, certificateStoreError)
+	}
+	return toIssuedCertificate(record), nil
+}
+
+func (g *grpcaCAServer) SearchCertificates(ctx context.Context, request *fulciogrpc.SearchCertificatesRequest) (*fulciogrpc.SearchCertificatesResponse, error) {
+	if err := g.checkCertificateLookup(ctx); err != nil {
+		return nil, err
+	}
+	pageSize := int(request.PageSize)
+	if pageSize < 0 {
+		return nil, handleFulcioGRPCError(ctx, codes.InvalidArgument, ReasonInvalidRequest, withField("page_size", fmt.Errorf("negative page size %d", pageSize)), invalidSearchRequest)
+	}
+	if pageSize == 0 || pageSize > maxSearchPageSize {
+		pageSize = maxSearchPageSize
+	}
+	query := certstore.Query{
+		Identity: request.Identity,
+		Issuer:   request.Issuer,
+		Limit:    pageSize,
+		Cursor:   request.PageToken,
+	}
+	if request.StartTime != nil {
+		query.Start = request.StartTime.AsTime()
+	}
+	if request.EndTime != nil {
+		query.End = request.EndTime.AsTime()
+	}
+
+	records, cursor, err := g.store.Search(ctx, query)
+	if errors.Is(err, certstore.ErrInvalidCursor) {
+		return nil, handleFulcioGRPCError(ctx, codes.InvalidArgument, ReasonInvalidRequest, withField("page_token", err), invalidSearchRequest)
+	}
+	if err != nil {
+		return nil, handleFulcioGRPCError(ctx, codes.Internal, ReasonCertificateStoreError, err, certificateStoreError)
+	}
+	resp := &fulciogrpc.SearchCertificatesResponse{
+		NextPageToken: cursor,
+	}
+	for _, record := range records {
+		resp.Certificates = append(resp.Certificates, toIssuedCertificate(record))
+	}
+	return resp, nil
+}
+
+// checkCertificateLookup returns an error unless the certificate store can be
+// queried.
+func (g *grpcaCAServer) checkCertificateLookup(ctx context.Context) error {
+	if g.store == nil {
+		return handleFulcioGRPCError(ctx, codes.Unimplemented, ReasonCertificateStoreDisabled, errors.New("certificate store not configured"), certificateStoreNotEnabled)
+	}
+	if !g.lookup {
+		return handleFulcioGRPCError(ctx, codes.Unimplemented, ReasonCertificateLookupDisabled, errors.New("certificate lookup not enabled"), certificateLookupNotEnabled)
+	}
+	return nil
+}
+
+func toIssuedCertificate(record *certstore.Record) *fulciogrpc.IssuedCertificate {
+	return &fulciogrpc.IssuedCertificate{
+		SerialNumber:            record.Serial,
+		Issuer:                  record.Issuer,
+		Identity:                record.Identity,
+		SubjectAlternativeNames: record.SubjectAlternativeNames,
+		NotBefore:               timestamppb.New(record.NotBefore),
+		NotAfter:                timestamppb.New(record.NotAfter),
+		IssueTime:               timestamppb.New(record.IssuedAt),
+		Chain: &fulciogrpc.CertificateChain{
+			Certificates: record.Chain,
+		},
+	}
 }
 
-func (g *grpcaCAServer) Watch(_ *health.HealthCheckRequest, _ health.Health_WatchServer) error {
-	return status.Error(codes.Unimplemented, "unimplemented")
+func (g *grpcaCAServer) Check(ctx context.Context, request *health.HealthCheckRequest) (*health.HealthCheckResponse, error) {
+	if g.health == nil {
+		return &health.HealthCheckResponse{Status: health.HealthCheckResponse_SERVING}, nil
+	}
+	return g.health.Check(ctx, request)
+}
+
+func (g *grpcaCAServer) Watch(request *health.HealthCheckRequest, stream health.Health_WatchServer) error {
+	if g.health == nil {
+		if err := stream.Send(&health.HealthCheckResponse{Status: health.HealthCheckResponse_SERVING}); err != nil {
+			return status.Error(codes.Canceled, "Stream has ended.")
+		}
+		<-stream.Context().Done()
+		return status.Error(codes.Canceled, "Stream has ended.")
+	}
+	return g.health.Watch(request, stream)
 }
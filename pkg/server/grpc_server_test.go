@@ -16,6 +16,7 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"crypto"
 	"crypto/ecdsa"
@@ -23,6 +24,7 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
+	"crypto/sha512"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/asn1"
@@ -34,6 +36,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -44,17 +47,23 @@ import (
 	"github.com/go-jose/go-jose/v4/jwt"
 	ctclient "github.com/google/certificate-transparency-go/client"
 	"github.com/google/certificate-transparency-go/jsonclient"
+	"github.com/google/go-cmp/cmp"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/sigstore/fulcio/pkg/audit"
 	"github.com/sigstore/fulcio/pkg/ca"
 	"github.com/sigstore/fulcio/pkg/ca/ephemeralca"
 	"github.com/sigstore/fulcio/pkg/certificate"
+	"github.com/sigstore/fulcio/pkg/certstore"
 	"github.com/sigstore/fulcio/pkg/config"
+	"github.com/sigstore/fulcio/pkg/ctl"
 	"github.com/sigstore/fulcio/pkg/generated/protobuf"
 	"github.com/sigstore/fulcio/pkg/identity"
 	"github.com/sigstore/sigstore/pkg/cryptoutils"
@@ -88,12 +97,12 @@ func passFulcioConfigThruContext(cfg *config.FulcioConfig) grpc.UnaryServerInter
 	}
 }
 
-func setupGRPCForTest(t *testing.T, cfg *config.FulcioConfig, ctl *ctclient.LogClient, ca ca.CertificateAuthority) (*grpc.Server, *grpc.ClientConn) {
+func setupGRPCForTest(t *testing.T, cfg *config.FulcioConfig, ct *ctclient.LogClient, ca ca.CertificateAuthority, opts ...GRPCCAServerOption) (*grpc.Server, *grpc.ClientConn) {
 	t.Helper()
 	lis = bufconn.Listen(bufSize)
 	s := grpc.NewServer(grpc.UnaryInterceptor(passFulcioConfigThruContext(cfg)))
 	ip := NewIssuerPool(cfg)
-	protobuf.RegisterCAServer(s, NewGRPCCAServer(ctl, ca, ip))
+	protobuf.RegisterCAServer(s, NewGRPCCAServer(ca, ip, append([]GRPCCAServerOption{withCTLog(ct)}, opts...)...))
 	go func() {
 		if err := s.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
 			t.Errorf("Server exited with error: %v", err)
@@ -345,6 +354,11 @@ func TestGetConfiguration(t *testing.T) {
 				t.Fatalf("expected no SPIFFE trust domain, got %v", iss.SpiffeTrustDomain)
 			}
 		}
+
+		profile := iss.CertificateProfile
+		if profile.GetDefaultLifetime().AsDuration() != 10*time.Minute || profile.GetSctDelivery() != protobuf.SCTDelivery_SCT_DELIVERY_EMBEDDED {
+			t.Fatalf("expected default certificate profile with embedded SCTs, got %v", profile)
+		}
 	}
 
 	if len(expectedIssuers) != 0 {
@@ -1873,11 +1887,11 @@ func TestAPIWithCSRChallenge(t *testing.T) {
 	}
 }
 
-// Tests API with insecure pub key
-func TestAPIWithInsecurePublicKey(t *testing.T) {
+// Tests API for issuing multiple certificates under one identity token
+func TestAPIWithMultipleKeys(t *testing.T) {
 	emailSigner, emailIssuer := newOIDCIssuer(t)
 
-	// Create a FulcioConfig that supports these issuers.
+	// Create a FulcioConfig that supports this issuer.
 	cfg, err := config.Read([]byte(fmt.Sprintf(`{
 		"OIDCIssuers": {
 			%q: {
@@ -1915,50 +1929,114 @@ func TestAPIWithInsecurePublicKey(t *testing.T) {
 
 	client := protobuf.NewCAClient(conn)
 
-	priv, err := rsa.GenerateKey(rand.Reader, 1024)
-	if err != nil {
-		t.Fatalf("GenerateKey() = %v", err)
-	}
-	pubBytes, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
-	if err != nil {
-		t.Fatalf("x509.MarshalPKIXPublicKey() = %v", err)
+	creds := &protobuf.Credentials{
+		Credentials: &protobuf.Credentials_OidcIdentityToken{
+			OidcIdentityToken: tok,
+		},
 	}
 
-	// Hit the API to have it sign our certificate.
-	_, err = client.CreateSigningCertificate(ctx, &protobuf.CreateSigningCertificateRequest{
-		Credentials: &protobuf.Credentials{
-			Credentials: &protobuf.Credentials_OidcIdentityToken{
-				OidcIdentityToken: tok,
+	var keys []*protobuf.SigningCertificateKey
+	for i := 0; i < 3; i++ {
+		pubBytes, proof := generateKeyAndProof(emailSubject, t)
+		keys = append(keys, &protobuf.SigningCertificateKey{
+			Key: &protobuf.SigningCertificateKey_PublicKeyRequest{
+				PublicKeyRequest: &protobuf.PublicKeyRequest{
+					PublicKey: &protobuf.PublicKey{
+						Content: pubBytes,
+					},
+					ProofOfPossession: proof,
+				},
 			},
-		},
-		Key: &protobuf.CreateSigningCertificateRequest_PublicKeyRequest{
+		})
+	}
+	// Sign the wrong subject for the last key
+	pubBytes, proof := generateKeyAndProof("bar@example.com", t)
+	keys = append(keys, &protobuf.SigningCertificateKey{
+		Key: &protobuf.SigningCertificateKey_PublicKeyRequest{
 			PublicKeyRequest: &protobuf.PublicKeyRequest{
 				PublicKey: &protobuf.PublicKey{
-					Content: string(cryptoutils.PEMEncode(cryptoutils.PublicKeyPEMType, pubBytes)),
+					Content: pubBytes,
 				},
-				ProofOfPossession: []byte{},
+				ProofOfPossession: proof,
 			},
 		},
 	})
-	if err == nil || !strings.Contains(err.Error(), "The public key supplied in the request is insecure") {
-		t.Fatalf("expected insecure public key error, got %v", err)
+
+	resp, err := client.CreateSigningCertificates(ctx, &protobuf.CreateSigningCertificatesRequest{
+		Credentials: creds,
+		Keys:        keys,
+	})
+	if err != nil {
+		t.Fatalf("CreateSigningCertificates() = %v", err)
+	}
+	if len(resp.Results) != len(keys) {
+		t.Fatalf("expected %d results, got %d", len(keys), len(resp.Results))
+	}
+
+	serials := map[string]bool{}
+	for i, result := range resp.Results[:3] {
+		if result.GetError() != nil {
+			t.Fatalf("unexpected error for key %d: %v", i, result.GetError())
+		}
+		leafCert := verifyResponse(result.GetSigningCertificate(), eca, emailIssuer, t)
+		if len(leafCert.EmailAddresses) != 1 || leafCert.EmailAddresses[0] != emailSubject {
+			t.Fatalf("subjects do not match: Expected %v, got %v", emailSubject, leafCert.EmailAddresses)
+		}
+		pub, err := cryptoutils.UnmarshalPEMToPublicKey([]byte(keys[i].GetPublicKeyRequest().PublicKey.Content))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := cryptoutils.EqualKeys(pub, leafCert.PublicKey); err != nil {
+			t.Fatalf("certificate %d does not match requested key: %v", i, err)
+		}
+		serials[leafCert.SerialNumber.String()] = true
+	}
+	if len(serials) != 3 {
+		t.Fatalf("expected 3 distinct certificates, got %d", len(serials))
+	}
+
+	failed := resp.Results[3].GetError()
+	if failed == nil {
+		t.Fatal("expected error for key with invalid proof of possession")
+	}
+	if codes.Code(failed.Code) != codes.InvalidArgument || failed.Message != invalidSignature {
+		t.Fatalf("unexpected error for invalid proof of possession: %v", failed)
 	}
+
+	// An empty request fails as a whole
+	_, err = client.CreateSigningCertificates(ctx, &protobuf.CreateSigningCertificatesRequest{Credentials: creds})
 	if status.Code(err) != codes.InvalidArgument {
-		t.Fatalf("expected invalid argument, got %v", status.Code(err))
+		t.Fatalf("expected invalid argument for empty request, got %v", err)
+	}
+
+	// An invalid token fails as a whole
+	_, err = client.CreateSigningCertificates(ctx, &protobuf.CreateSigningCertificatesRequest{
+		Credentials: &protobuf.Credentials{
+			Credentials: &protobuf.Credentials_OidcIdentityToken{
+				OidcIdentityToken: "not.a.token",
+			},
+		},
+		Keys: keys[:1],
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected invalid argument for invalid token, got %v", err)
 	}
 }
 
-// Tests API with no public key
-func TestAPIWithoutPublicKey(t *testing.T) {
+// Tests API with a requested certificate validity
+func TestAPIWithRequestedValidity(t *testing.T) {
 	emailSigner, emailIssuer := newOIDCIssuer(t)
 
-	// Create a FulcioConfig that supports these issuers.
+	// Create a FulcioConfig that allows longer lived certificates for this issuer.
 	cfg, err := config.Read([]byte(fmt.Sprintf(`{
 		"OIDCIssuers": {
 			%q: {
 				"IssuerURL": %q,
 				"ClientID": "sigstore",
-				"Type": "email"
+				"Type": "email",
+				"DefaultCertificateLifetime": "1h",
+				"MaxCertificateLifetime": "4h",
+				"CertificateBackdate": "1m"
 			}
 		}
 	}`, emailIssuer, emailIssuer)))
@@ -1990,22 +2068,61 @@ func TestAPIWithoutPublicKey(t *testing.T) {
 
 	client := protobuf.NewCAClient(conn)
 
-	// Test with no key proto specified
-	_, err = client.CreateSigningCertificate(ctx, &protobuf.CreateSigningCertificateRequest{
-		Credentials: &protobuf.Credentials{
-			Credentials: &protobuf.Credentials_OidcIdentityToken{
-				OidcIdentityToken: tok,
-			},
+	tests := map[string]struct {
+		requested *durationpb.Duration
+		want      time.Duration
+	}{
+		"default lifetime": {
+			want: time.Hour,
+		},
+		"requested lifetime": {
+			requested: durationpb.New(2 * time.Hour),
+			want:      2 * time.Hour,
+		},
+		"clamped to max lifetime": {
+			requested: durationpb.New(24 * time.Hour),
+			want:      4 * time.Hour,
 		},
-	})
-	if err == nil || !strings.Contains(err.Error(), "The public key supplied in the request could not be parsed") {
-		t.Fatalf("expected parsing public key error, got %v", err)
 	}
-	if status.Code(err) != codes.InvalidArgument {
-		t.Fatalf("expected invalid argument, got %v", status.Code(err))
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pubBytes, proof := generateKeyAndProof(emailSubject, t)
+			resp, err := client.CreateSigningCertificate(ctx, &protobuf.CreateSigningCertificateRequest{
+				Credentials: &protobuf.Credentials{
+					Credentials: &protobuf.Credentials_OidcIdentityToken{
+						OidcIdentityToken: tok,
+					},
+				},
+				Key: &protobuf.CreateSigningCertificateRequest_PublicKeyRequest{
+					PublicKeyRequest: &protobuf.PublicKeyRequest{
+						PublicKey: &protobuf.PublicKey{
+							Content: pubBytes,
+						},
+						ProofOfPossession: proof,
+					},
+				},
+				RequestedValidity: test.requested,
+			})
+			if err != nil {
+				t.Fatalf("SigningCert() = %v", err)
+			}
+			block, _ := pem.Decode([]byte(resp.GetSignedCertificateEmbeddedSct().Chain.Certificates[0]))
+			if block == nil {
+				t.Fatal("missing PEM data")
+			}
+			leafCert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				t.Fatalf("failed to parse the received leaf cert: %v", err)
+			}
+			// Validity includes the one minute backdate
+			if got := leafCert.NotAfter.Sub(leafCert.NotBefore); got != test.want+time.Minute {
+				t.Fatalf("expected %v validity, got %v", test.want+time.Minute, got)
+			}
+		})
 	}
 
-	// Test with no public key specified
+	// A negative validity is rejected
+	pubBytes, proof := generateKeyAndProof(emailSubject, t)
 	_, err = client.CreateSigningCertificate(ctx, &protobuf.CreateSigningCertificateRequest{
 		Credentials: &protobuf.Credentials{
 			Credentials: &protobuf.Credentials_OidcIdentityToken{
@@ -2013,28 +2130,31 @@ func TestAPIWithoutPublicKey(t *testing.T) {
 			},
 		},
 		Key: &protobuf.CreateSigningCertificateRequest_PublicKeyRequest{
-			PublicKeyRequest: &protobuf.PublicKeyRequest{},
+			PublicKeyRequest: &protobuf.PublicKeyRequest{
+				PublicKey: &protobuf.PublicKey{
+					Content: pubBytes,
+				},
+				ProofOfPossession: proof,
+			},
 		},
+		RequestedValidity: durationpb.New(-time.Hour),
 	})
-	if err == nil || !strings.Contains(err.Error(), "The public key supplied in the request could not be parsed") {
-		t.Fatalf("expected parsing public key error, got %v", err)
-	}
 	if status.Code(err) != codes.InvalidArgument {
-		t.Fatalf("expected invalid argument, got %v", status.Code(err))
+		t.Fatalf("expected invalid argument for negative validity, got %v", err)
 	}
 }
 
-// Tests API with invalid challenge as proof of possession of private key
-func TestAPIWithInvalidChallenge(t *testing.T) {
+// Tests that tokens from an issuer requiring single-use tokens can't be reused
+func TestAPIWithSingleUseToken(t *testing.T) {
 	emailSigner, emailIssuer := newOIDCIssuer(t)
 
-	// Create a FulcioConfig that supports these issuers.
 	cfg, err := config.Read([]byte(fmt.Sprintf(`{
 		"OIDCIssuers": {
 			%q: {
 				"IssuerURL": %q,
 				"ClientID": "sigstore",
-				"Type": "email"
+				"Type": "email",
+				"SingleUseTokens": true
 			}
 		}
 	}`, emailIssuer, emailIssuer)))
@@ -2043,33 +2163,49 @@ func TestAPIWithInvalidChallenge(t *testing.T) {
 	}
 
 	emailSubject := "foo@example.com"
-
-	// Create an OIDC token using this issuer's signer.
 	tok, err := jwt.Signed(emailSigner).Claims(jwt.Claims{
 		Issuer:   emailIssuer,
 		IssuedAt: jwt.NewNumericDate(time.Now()),
 		Expiry:   jwt.NewNumericDate(time.Now().Add(30 * time.Minute)),
 		Subject:  emailSubject,
 		Audience: jwt.Audience{"sigstore"},
+		ID:       "token-1",
 	}).Claims(customClaims{Email: emailSubject, EmailVerified: true}).Serialize()
 	if err != nil {
 		t.Fatalf("Serialize() = %v", err)
 	}
 
 	ctClient, eca := createCA(cfg, t)
-	ctx := context.Background()
-	server, conn := setupGRPCForTest(t, cfg, ctClient, eca)
-	defer func() {
-		server.Stop()
-		conn.Close()
-	}()
+	store, err := identity.NewMemoryReplayStore(10)
+	if err != nil {
+		t.Fatalf("NewMemoryReplayStore() = %v", err)
+	}
+	g := NewGRPCCAServer(eca, identity.PreventReplay(NewIssuerPool(cfg), store), withCTLog(ctClient))
+	ctx := config.With(context.Background(), cfg)
 
-	client := protobuf.NewCAClient(conn)
+	request := func() error {
+		pubBytes, proof := generateKeyAndProof(emailSubject, t)
+		_, err := g.CreateSigningCertificate(ctx, &protobuf.CreateSigningCertificateRequest{
+			Credentials: &protobuf.Credentials{
+				Credentials: &protobuf.Credentials_OidcIdentityToken{
+					OidcIdentityToken: tok,
+				},
+			},
+			Key: &protobuf.CreateSigningCertificateRequest_PublicKeyRequest{
+				PublicKeyRequest: &protobuf.PublicKeyRequest{
+					PublicKey: &protobuf.PublicKey{
+						Content: pubBytes,
+					},
+					ProofOfPossession: proof,
+				},
+			},
+		})
+		return err
+	}
 
+	// A request failing validation doesn't use up the token
 	pubBytes, _ := generateKeyAndProof(emailSubject, t)
-	_, invalidProof := generateKeyAndProof(emailSubject, t)
-
-	_, err = client.CreateSigningCertificate(ctx, &protobuf.CreateSigningCertificateRequest{
+	_, err = g.CreateSigningCertificate(ctx, &protobuf.CreateSigningCertificateRequest{
 		Credentials: &protobuf.Credentials{
 			Credentials: &protobuf.Credentials_OidcIdentityToken{
 				OidcIdentityToken: tok,
@@ -2080,23 +2216,70 @@ func TestAPIWithInvalidChallenge(t *testing.T) {
 				PublicKey: &protobuf.PublicKey{
 					Content: pubBytes,
 				},
-				ProofOfPossession: invalidProof,
+				ProofOfPossession: []byte("invalid"),
 			},
 		},
 	})
-	if err == nil || !strings.Contains(err.Error(), "The signature supplied in the request could not be verified") {
-		t.Fatalf("expected invalid signature error, got %v", err)
+	if status.Code(err) != codes.InvalidArgument || status.Convert(err).Message() != invalidSignature {
+		t.Fatalf("expected invalid proof of possession to be rejected, got %v", err)
 	}
-	if status.Code(err) != codes.InvalidArgument {
-		t.Fatalf("expected invalid argument, got %v", status.Code(err))
+
+	if err := request(); err != nil {
+		t.Fatalf("SigningCert() = %v", err)
+	}
+	err = request()
+	if status.Code(err) != codes.InvalidArgument || status.Convert(err).Message() != identityTokenReplayed {
+		t.Fatalf("expected replayed token to be rejected, got %v", err)
+	}
+
+	// A token is used once for every key of a batch
+	tok, err = jwt.Signed(emailSigner).Claims(jwt.Claims{
+		Issuer:   emailIssuer,
+		IssuedAt: jwt.NewNumericDate(time.Now()),
+		Expiry:   jwt.NewNumericDate(time.Now().Add(30 * time.Minute)),
+		Subject:  emailSubject,
+		Audience: jwt.Audience{"sigstore"},
+		ID:       "token-2",
+	}).Claims(customClaims{Email: emailSubject, EmailVerified: true}).Serialize()
+	if err != nil {
+		t.Fatalf("Serialize() = %v", err)
+	}
+	batch := &protobuf.CreateSigningCertificatesRequest{
+		Credentials: &protobuf.Credentials{
+			Credentials: &protobuf.Credentials_OidcIdentityToken{
+				OidcIdentityToken: tok,
+			},
+		},
+	}
+	for i := 0; i < 2; i++ {
+		pubBytes, proof := generateKeyAndProof(emailSubject, t)
+		batch.Keys = append(batch.Keys, &protobuf.SigningCertificateKey{
+			Key: &protobuf.SigningCertificateKey_PublicKeyRequest{
+				PublicKeyRequest: &protobuf.PublicKeyRequest{
+					PublicKey:         &protobuf.PublicKey{Content: pubBytes},
+					ProofOfPossession: proof,
+				},
+			},
+		})
+	}
+	resp, err := g.CreateSigningCertificates(ctx, batch)
+	if err != nil {
+		t.Fatalf("CreateSigningCertificates() = %v", err)
+	}
+	for i, result := range resp.Results {
+		if result.GetError() != nil {
+			t.Errorf("key %d: %v", i, result.GetError())
+		}
+	}
+	if err := request(); status.Code(err) != codes.InvalidArgument || status.Convert(err).Message() != identityTokenReplayed {
+		t.Fatalf("expected replayed token to be rejected, got %v", err)
 	}
 }
 
-// Tests API with an invalid CSR.
-func TestAPIWithInvalidCSR(t *testing.T) {
+// Tests proofs of possession signed with an explicit algorithm and hash
+func TestAPIWithProofAlgorithm(t *testing.T) {
 	emailSigner, emailIssuer := newOIDCIssuer(t)
 
-	// Create a FulcioConfig that supports this issuer.
 	cfg, err := config.Read([]byte(fmt.Sprintf(`{
 		"OIDCIssuers": {
 			%q: {
@@ -2111,8 +2294,6 @@ func TestAPIWithInvalidCSR(t *testing.T) {
 	}
 
 	emailSubject := "foo@example.com"
-
-	// Create an OIDC token using this issuer's signer.
 	tok, err := jwt.Signed(emailSigner).Claims(jwt.Claims{
 		Issuer:   emailIssuer,
 		IssuedAt: jwt.NewNumericDate(time.Now()),
@@ -2125,45 +2306,126 @@ func TestAPIWithInvalidCSR(t *testing.T) {
 	}
 
 	ctClient, eca := createCA(cfg, t)
-	ctx := context.Background()
-	server, conn := setupGRPCForTest(t, cfg, ctClient, eca)
-	defer func() {
-		server.Stop()
-		conn.Close()
-	}()
+	g := NewGRPCCAServer(eca, NewIssuerPool(cfg), withCTLog(ctClient))
+	ctx := config.With(context.Background(), cfg)
 
-	client := protobuf.NewCAClient(conn)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaDigest := sha256.Sum256([]byte(emailSubject))
+	pssProof, err := rsa.SignPSS(rand.Reader, rsaKey, crypto.SHA256, rsaDigest[:], nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecDigest := sha512.Sum384([]byte(emailSubject))
+	ecProof, err := ecdsa.SignASN1(rand.Reader, ecKey, ecDigest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
 
-	_, err = client.CreateSigningCertificate(ctx, &protobuf.CreateSigningCertificateRequest{
-		Credentials: &protobuf.Credentials{
-			Credentials: &protobuf.Credentials_OidcIdentityToken{
-				OidcIdentityToken: tok,
-			},
+	tests := map[string]struct {
+		pub       crypto.PublicKey
+		proof     []byte
+		algorithm protobuf.PublicKeyAlgorithm
+		hash      protobuf.HashAlgorithm
+		wantErr   string
+	}{
+		"RSA-PSS": {
+			pub:       rsaKey.Public(),
+			proof:     pssProof,
+			algorithm: protobuf.PublicKeyAlgorithm_RSA_PSS,
 		},
-		Key: &protobuf.CreateSigningCertificateRequest_CertificateSigningRequest{
-			CertificateSigningRequest: []byte("invalid"),
+		"RSA-PSS verified as PKCS#1 v1.5": {
+			pub:     rsaKey.Public(),
+			proof:   pssProof,
+			wantErr: invalidSignature,
+		},
+		"ECDSA P-384 with SHA-384": {
+			pub:       ecKey.Public(),
+			proof:     ecProof,
+			algorithm: protobuf.PublicKeyAlgorithm_ECDSA,
+			hash:      protobuf.HashAlgorithm_SHA2_384,
+		},
+		"ECDSA P-384 with inferred algorithm": {
+			pub:   ecKey.Public(),
+			proof: ecProof,
+			hash:  protobuf.HashAlgorithm_SHA2_384,
+		},
+		"ECDSA P-384 with wrong hash": {
+			pub:       ecKey.Public(),
+			proof:     ecProof,
+			algorithm: protobuf.PublicKeyAlgorithm_ECDSA,
+			wantErr:   invalidSignature,
+		},
+		"algorithm mismatching key": {
+			pub:       ecKey.Public(),
+			proof:     ecProof,
+			algorithm: protobuf.PublicKeyAlgorithm_RSA_PSS,
+			hash:      protobuf.HashAlgorithm_SHA2_384,
+			wantErr:   invalidSignature,
+		},
+		"unknown hash": {
+			pub:     ecKey.Public(),
+			proof:   ecProof,
+			hash:    protobuf.HashAlgorithm(42),
+			wantErr: unsupportedSignatureAlgorithm,
 		},
-	})
-
-	if err == nil || !strings.Contains(err.Error(), "The certificate signing request could not be parsed") {
-		t.Fatalf("expected invalid signature error, got %v", err)
 	}
-	if status.Code(err) != codes.InvalidArgument {
-		t.Fatalf("expected invalid argument, got %v", status.Code(err))
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pubBytes, err := cryptoutils.MarshalPublicKeyToPEM(test.pub)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = g.CreateSigningCertificate(ctx, &protobuf.CreateSigningCertificateRequest{
+				Credentials: &protobuf.Credentials{
+					Credentials: &protobuf.Credentials_OidcIdentityToken{
+						OidcIdentityToken: tok,
+					},
+				},
+				Key: &protobuf.CreateSigningCertificateRequest_PublicKeyRequest{
+					PublicKeyRequest: &protobuf.PublicKeyRequest{
+						PublicKey: &protobuf.PublicKey{
+							Algorithm:     test.algorithm,
+							Content:       string(pubBytes),
+							HashAlgorithm: test.hash,
+						},
+						ProofOfPossession: test.proof,
+					},
+				},
+			})
+			if test.wantErr == "" {
+				if err != nil {
+					t.Fatalf("CreateSigningCertificate() = %v", err)
+				}
+				return
+			}
+			if status.Code(err) != codes.InvalidArgument || status.Convert(err).Message() != test.wantErr {
+				t.Fatalf("expected %q, got %v", test.wantErr, err)
+			}
+		})
 	}
 }
 
-// Tests API with unsigned CSR, which will fail signature verification.
-func TestAPIWithInvalidCSRSignature(t *testing.T) {
+// Tests that keys not allowed by the issuer's key policy are rejected
+func TestAPIWithKeyPolicy(t *testing.T) {
 	emailSigner, emailIssuer := newOIDCIssuer(t)
 
-	// Create a FulcioConfig that supports this issuer.
 	cfg, err := config.Read([]byte(fmt.Sprintf(`{
 		"OIDCIssuers": {
 			%q: {
 				"IssuerURL": %q,
 				"ClientID": "sigstore",
-				"Type": "email"
+				"Type": "email",
+				"KeyPolicy": {
+					"Algorithms": ["ecdsa"],
+					"ECDSACurves": ["P-384"]
+				}
 			}
 		}
 	}`, emailIssuer, emailIssuer)))
@@ -2172,8 +2434,6 @@ func TestAPIWithInvalidCSRSignature(t *testing.T) {
 	}
 
 	emailSubject := "foo@example.com"
-
-	// Create an OIDC token using this issuer's signer.
 	tok, err := jwt.Signed(emailSigner).Claims(jwt.Claims{
 		Issuer:   emailIssuer,
 		IssuedAt: jwt.NewNumericDate(time.Now()),
@@ -2186,23 +2446,942 @@ func TestAPIWithInvalidCSRSignature(t *testing.T) {
 	}
 
 	ctClient, eca := createCA(cfg, t)
-	ctx := context.Background()
-	server, conn := setupGRPCForTest(t, cfg, ctClient, eca)
-	defer func() {
-		server.Stop()
-		conn.Close()
-	}()
+	g := NewGRPCCAServer(eca, NewIssuerPool(cfg), withCTLog(ctClient))
+	ctx := config.With(context.Background(), cfg)
 
-	client := protobuf.NewCAClient(conn)
+	request := func(curve elliptic.Curve) error {
+		priv, err := ecdsa.GenerateKey(curve, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pubBytes, err := cryptoutils.MarshalPublicKeyToPEM(priv.Public())
+		if err != nil {
+			t.Fatal(err)
+		}
+		digest := sha256.Sum256([]byte(emailSubject))
+		proof, err := ecdsa.SignASN1(rand.Reader, priv, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = g.CreateSigningCertificate(ctx, &protobuf.CreateSigningCertificateRequest{
+			Credentials: &protobuf.Credentials{
+				Credentials: &protobuf.Credentials_OidcIdentityToken{
+					OidcIdentityToken: tok,
+				},
+			},
+			Key: &protobuf.CreateSigningCertificateRequest_PublicKeyRequest{
+				PublicKeyRequest: &protobuf.PublicKeyRequest{
+					PublicKey: &protobuf.PublicKey{
+						Content: string(pubBytes),
+					},
+					ProofOfPossession: proof,
+				},
+			},
+		})
+		return err
+	}
 
-	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
-	if err != nil {
-		t.Fatalf("error generating private key: %v", err)
+	if err := request(elliptic.P384()); err != nil {
+		t.Fatalf("CreateSigningCertificate() = %v", err)
 	}
-	csrTmpl := &x509.CertificateRequest{Subject: pkix.Name{CommonName: "test"}}
-	derCSR, err := x509.CreateCertificateRequest(rand.Reader, csrTmpl, priv)
-	if err != nil {
-		t.Fatalf("error creating CSR: %v", err)
+	err = request(elliptic.P256())
+	if status.Code(err) != codes.InvalidArgument || status.Convert(err).Message() != keyPolicyViolation {
+		t.Fatalf("expected P-256 key to be rejected, got %v", err)
+	}
+}
+
+// Tests that certificates are only issued for identities allowed by the
+// issuer's policy
+func TestAPIWithPolicy(t *testing.T) {
+	emailSigner, emailIssuer := newOIDCIssuer(t)
+
+	cfg, err := config.Read([]byte(fmt.Sprintf(`{
+		"OIDCIssuers": {
+			%q: {
+				"IssuerURL": %q,
+				"ClientID": "sigstore",
+				"Type": "email",
+				"Policy": "claims.email_verified && principal.endsWith('@example.com')"
+			}
+		}
+	}`, emailIssuer, emailIssuer)))
+	if err != nil {
+		t.Fatalf("config.Read() = %v", err)
+	}
+
+	ctClient, eca := createCA(cfg, t)
+	g := NewGRPCCAServer(eca, NewIssuerPool(cfg), withCTLog(ctClient))
+	ctx := config.With(context.Background(), cfg)
+
+	request := func(emailSubject string) error {
+		tok, err := jwt.Signed(emailSigner).Claims(jwt.Claims{
+			Issuer:   emailIssuer,
+			IssuedAt: jwt.NewNumericDate(time.Now()),
+			Expiry:   jwt.NewNumericDate(time.Now().Add(30 * time.Minute)),
+			Subject:  emailSubject,
+			Audience: jwt.Audience{"sigstore"},
+		}).Claims(customClaims{Email: emailSubject, EmailVerified: true}).Serialize()
+		if err != nil {
+			t.Fatalf("Serialize() = %v", err)
+		}
+		pubBytes, proof := generateKeyAndProof(emailSubject, t)
+		_, err = g.CreateSigningCertificate(ctx, &protobuf.CreateSigningCertificateRequest{
+			Credentials: &protobuf.Credentials{
+				Credentials: &protobuf.Credentials_OidcIdentityToken{
+					OidcIdentityToken: tok,
+				},
+			},
+			Key: &protobuf.CreateSigningCertificateRequest_PublicKeyRequest{
+				PublicKeyRequest: &protobuf.PublicKeyRequest{
+					PublicKey: &protobuf.PublicKey{
+						Content: pubBytes,
+					},
+					ProofOfPossession: proof,
+				},
+			},
+		})
+		return err
+	}
+
+	if err := request("foo@example.com"); err != nil {
+		t.Fatalf("CreateSigningCertificate() = %v", err)
+	}
+	err = request("foo@example.org")
+	if status.Code(err) != codes.PermissionDenied || status.Convert(err).Message() != policyDenied {
+		t.Fatalf("expected identity to be denied by policy, got %v", err)
+	}
+}
+
+// Tests that previews describe the certificate without calling the CA
+func TestAPIPreviewSigningCertificate(t *testing.T) {
+	emailSigner, emailIssuer := newOIDCIssuer(t)
+
+	cfg, err := config.Read([]byte(fmt.Sprintf(`{
+		"OIDCIssuers": {
+			%q: {
+				"IssuerURL": %q,
+				"ClientID": "sigstore",
+				"Type": "email",
+				"DefaultCertificateLifetime": "1h"
+			}
+		}
+	}`, emailIssuer, emailIssuer)))
+	if err != nil {
+		t.Fatalf("config.Read() = %v", err)
+	}
+
+	emailSubject := "foo@example.com"
+	tok, err := jwt.Signed(emailSigner).Claims(jwt.Claims{
+		Issuer:   emailIssuer,
+		IssuedAt: jwt.NewNumericDate(time.Now()),
+		Expiry:   jwt.NewNumericDate(time.Now().Add(30 * time.Minute)),
+		Subject:  emailSubject,
+		Audience: jwt.Audience{"sigstore"},
+	}).Claims(customClaims{Email: emailSubject, EmailVerified: true}).Serialize()
+	if err != nil {
+		t.Fatalf("Serialize() = %v", err)
+	}
+
+	// The CA fails every request, so a preview must not use it
+	server, conn := setupGRPCForTest(t, cfg, nil, &FailingCertificateAuthority{})
+	defer func() {
+		server.Stop()
+		conn.Close()
+	}()
+	client := protobuf.NewCAClient(conn)
+
+	pubBytes, proof := generateKeyAndProof(emailSubject, t)
+	preview, err := client.PreviewSigningCertificate(context.Background(), &protobuf.CreateSigningCertificateRequest{
+		Credentials: &protobuf.Credentials{
+			Credentials: &protobuf.Credentials_OidcIdentityToken{
+				OidcIdentityToken: tok,
+			},
+		},
+		Key: &protobuf.CreateSigningCertificateRequest_PublicKeyRequest{
+			PublicKeyRequest: &protobuf.PublicKeyRequest{
+				PublicKey: &protobuf.PublicKey{
+					Content: pubBytes,
+				},
+				ProofOfPossession: proof,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("PreviewSigningCertificate() = %v", err)
+	}
+
+	if preview.Issuer != emailIssuer || preview.Identity != emailSubject {
+		t.Errorf("got issuer %q and identity %q", preview.Issuer, preview.Identity)
+	}
+	if diff := cmp.Diff([]string{emailSubject}, preview.SubjectAlternativeNames); diff != "" {
+		t.Errorf("unexpected SANs (-want +got):\n%s", diff)
+	}
+	if got := preview.NotAfter.AsTime().Sub(preview.NotBefore.AsTime()); got != time.Hour {
+		t.Errorf("got validity %v, wanted 1h", got)
+	}
+	if diff := cmp.Diff([]string{"DigitalSignature"}, preview.KeyUsages); diff != "" {
+		t.Errorf("unexpected key usages (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"CodeSigning"}, preview.ExtendedKeyUsages); diff != "" {
+		t.Errorf("unexpected extended key usages (-want +got):\n%s", diff)
+	}
+	extensions := map[string]string{}
+	for _, ext := range preview.Extensions {
+		extensions[ext.Name] = ext.Value
+	}
+	wantExtensions := map[string]string{
+		"Issuer (deprecated)": emailIssuer,
+		"Issuer (V2)":         emailIssuer,
+	}
+	if diff := cmp.Diff(wantExtensions, extensions); diff != "" {
+		t.Errorf("unexpected extensions (-want +got):\n%s", diff)
+	}
+
+	// The proof of possession is checked
+	_, err = client.PreviewSigningCertificate(context.Background(), &protobuf.CreateSigningCertificateRequest{
+		Credentials: &protobuf.Credentials{
+			Credentials: &protobuf.Credentials_OidcIdentityToken{
+				OidcIdentityToken: tok,
+			},
+		},
+		Key: &protobuf.CreateSigningCertificateRequest_PublicKeyRequest{
+			PublicKeyRequest: &protobuf.PublicKeyRequest{
+				PublicKey: &protobuf.PublicKey{
+					Content: pubBytes,
+				},
+				ProofOfPossession: []byte("not a signature"),
+			},
+		},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected invalid argument for bad proof of possession, got %v", err)
+	}
+}
+
+// Tests that explanations report the authentication step that failed
+func TestAPIExplainIdentityToken(t *testing.T) {
+	emailSigner, emailIssuer := newOIDCIssuer(t)
+
+	cfg, err := config.Read([]byte(fmt.Sprintf(`{
+		"OIDCIssuers": {
+			%q: {
+				"IssuerURL": %q,
+				"ClientID": "sigstore",
+				"Type": "email",
+				"Policy": "principal.endsWith('@example.com')"
+			}
+		}
+	}`, emailIssuer, emailIssuer)))
+	if err != nil {
+		t.Fatalf("config.Read() = %v", err)
+	}
+
+	server, conn := setupGRPCForTest(t, cfg, nil, &FailingCertificateAuthority{})
+	defer func() {
+		server.Stop()
+		conn.Close()
+	}()
+	client := protobuf.NewCAClient(conn)
+
+	token := func(issuer, audience, subject string, emailVerified bool) string {
+		tok, err := jwt.Signed(emailSigner).Claims(jwt.Claims{
+			Issuer:   issuer,
+			IssuedAt: jwt.NewNumericDate(time.Now()),
+			Expiry:   jwt.NewNumericDate(time.Now().Add(30 * time.Minute)),
+			Subject:  subject,
+			Audience: jwt.Audience{audience},
+		}).Claims(customClaims{Email: subject, EmailVerified: emailVerified}).Serialize()
+		if err != nil {
+			t.Fatalf("Serialize() = %v", err)
+		}
+		return tok
+	}
+
+	tests := map[string]struct {
+		token string
+		// reports whether the explanation is as expected
+		check func(*protobuf.IdentityTokenExplanation) bool
+	}{
+		"malformed token": {
+			token: "not-a-jwt",
+			check: func(e *protobuf.IdentityTokenExplanation) bool { return e.ParseError != "" },
+		},
+		"unknown issuer": {
+			token: token("https://unknown.example.com", "sigstore", "foo@example.com", true),
+			check: func(e *protobuf.IdentityTokenExplanation) bool {
+				return e.MatchError != "" && e.Issuer == "https://unknown.example.com"
+			},
+		},
+		"wrong audience": {
+			token: token(emailIssuer, "other", "foo@example.com", true),
+			check: func(e *protobuf.IdentityTokenExplanation) bool {
+				return strings.Contains(e.VerificationError, "audience") && e.IssuerType == "email"
+			},
+		},
+		"unverified email": {
+			token: token(emailIssuer, "sigstore", "foo@example.com", false),
+			check: func(e *protobuf.IdentityTokenExplanation) bool {
+				return strings.Contains(e.ClaimsError, "email_verified") && e.VerificationError == ""
+			},
+		},
+		"valid token": {
+			token: token(emailIssuer, "sigstore", "foo@example.com", true),
+			check: func(e *protobuf.IdentityTokenExplanation) bool {
+				return e.PrincipalName == "foo@example.com" && e.ClaimsError == "" && e.PolicyError == "" && e.IssuerType == "email"
+			},
+		},
+		"denied by policy": {
+			token: token(emailIssuer, "sigstore", "foo@example.org", true),
+			check: func(e *protobuf.IdentityTokenExplanation) bool {
+				return e.PrincipalName == "foo@example.org" && strings.Contains(e.PolicyError, "denied by issuer policy")
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			e, err := client.ExplainIdentityToken(context.Background(), &protobuf.ExplainIdentityTokenRequest{
+				Credentials: &protobuf.Credentials{
+					Credentials: &protobuf.Credentials_OidcIdentityToken{
+						OidcIdentityToken: test.token,
+					},
+				},
+			})
+			if err != nil {
+				t.Fatalf("ExplainIdentityToken() = %v", err)
+			}
+			if !test.check(e) {
+				t.Errorf("unexpected explanation %v", e)
+			}
+		})
+	}
+}
+
+// Tests that certificate requests are audited
+func TestAPIAuditEvents(t *testing.T) {
+	emailSigner, emailIssuer := newOIDCIssuer(t)
+
+	// Create a FulcioConfig that supports this issuer.
+	cfg, err := config.Read([]byte(fmt.Sprintf(`{
+		"OIDCIssuers": {
+			%q: {
+				"IssuerURL": %q,
+				"ClientID": "sigstore",
+				"Type": "email"
+			}
+		}
+	}`, emailIssuer, emailIssuer)))
+	if err != nil {
+		t.Fatalf("config.Read() = %v", err)
+	}
+
+	emailSubject := "foo@example.com"
+
+	// Create an OIDC token using this issuer's signer.
+	tok, err := jwt.Signed(emailSigner).Claims(jwt.Claims{
+		Issuer:   emailIssuer,
+		IssuedAt: jwt.NewNumericDate(time.Now()),
+		Expiry:   jwt.NewNumericDate(time.Now().Add(30 * time.Minute)),
+		Subject:  emailSubject,
+		Audience: jwt.Audience{"sigstore"},
+	}).Claims(customClaims{Email: emailSubject, EmailVerified: true}).Serialize()
+	if err != nil {
+		t.Fatalf("Serialize() = %v", err)
+	}
+
+	var buf bytes.Buffer
+	ctClient, eca := createCA(cfg, t)
+	ctx := context.Background()
+	server, conn := setupGRPCForTest(t, cfg, ctClient, eca, WithAuditLogger(audit.NewLogger("ephemeralca", audit.NewWriterSink(&buf))))
+	defer func() {
+		server.Stop()
+		conn.Close()
+	}()
+
+	client := protobuf.NewCAClient(conn)
+
+	pubBytes, proof := generateKeyAndProof(emailSubject, t)
+	resp, err := client.CreateSigningCertificate(ctx, &protobuf.CreateSigningCertificateRequest{
+		Credentials: &protobuf.Credentials{
+			Credentials: &protobuf.Credentials_OidcIdentityToken{
+				OidcIdentityToken: tok,
+			},
+		},
+		Key: &protobuf.CreateSigningCertificateRequest_PublicKeyRequest{
+			PublicKeyRequest: &protobuf.PublicKeyRequest{
+				PublicKey: &protobuf.PublicKey{
+					Content: pubBytes,
+				},
+				ProofOfPossession: proof,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("SigningCert() = %v", err)
+	}
+	leafCert := verifyResponse(resp, eca, emailIssuer, t)
+
+	// Proof of possession for a different subject fails
+	pubBytes, proof = generateKeyAndProof("bar@example.com", t)
+	_, err = client.CreateSigningCertificate(ctx, &protobuf.CreateSigningCertificateRequest{
+		Credentials: &protobuf.Credentials{
+			Credentials: &protobuf.Credentials_OidcIdentityToken{
+				OidcIdentityToken: tok,
+			},
+		},
+		Key: &protobuf.CreateSigningCertificateRequest_PublicKeyRequest{
+			PublicKeyRequest: &protobuf.PublicKeyRequest{
+				PublicKey: &protobuf.PublicKey{
+					Content: pubBytes,
+				},
+				ProofOfPossession: proof,
+			},
+		},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+
+	var events []audit.Event
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var e audit.Event
+		if err := dec.Decode(&e); err != nil {
+			t.Fatalf("decoding audit event: %v", err)
+		}
+		events = append(events, e)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 audit events, got %d", len(events))
+	}
+
+	success := events[0]
+	if success.Outcome != audit.OutcomeSuccess || success.Serial != leafCert.SerialNumber.String() ||
+		success.Issuer != emailIssuer || success.IssuerType != string(config.IssuerTypeEmail) ||
+		success.Principal != emailSubject || success.CABackend != "ephemeralca" {
+		t.Errorf("unexpected success event: %+v", success)
+	}
+	if len(success.SubjectAlternativeNames) != 1 || success.SubjectAlternativeNames[0] != emailSubject {
+		t.Errorf("unexpected subject alternative names: %v", success.SubjectAlternativeNames)
+	}
+	if success.PublicKeyFingerprint == "" || success.SCTLogID == "" || success.SCTTimestamp == nil {
+		t.Errorf("expected public key fingerprint and SCT in success event: %+v", success)
+	}
+
+	failure := events[1]
+	if failure.Outcome != audit.OutcomeFailure || failure.Reason != ReasonPOPSignatureInvalid || failure.Message != invalidSignature ||
+		failure.Code != codes.InvalidArgument.String() || failure.Principal != emailSubject || failure.Serial != "" {
+		t.Errorf("unexpected failure event: %+v", failure)
+	}
+}
+
+// Tests looking up certificates in the certificate store
+func TestAPICertificateStore(t *testing.T) {
+	emailSigner, emailIssuer := newOIDCIssuer(t)
+
+	// Create a FulcioConfig that supports this issuer.
+	cfg, err := config.Read([]byte(fmt.Sprintf(`{
+		"OIDCIssuers": {
+			%q: {
+				"IssuerURL": %q,
+				"ClientID": "sigstore",
+				"Type": "email"
+			}
+		}
+	}`, emailIssuer, emailIssuer)))
+	if err != nil {
+		t.Fatalf("config.Read() = %v", err)
+	}
+
+	emailSubject := "foo@example.com"
+
+	// Create an OIDC token using this issuer's signer.
+	tok, err := jwt.Signed(emailSigner).Claims(jwt.Claims{
+		Issuer:   emailIssuer,
+		IssuedAt: jwt.NewNumericDate(time.Now()),
+		Expiry:   jwt.NewNumericDate(time.Now().Add(30 * time.Minute)),
+		Subject:  emailSubject,
+		Audience: jwt.Audience{"sigstore"},
+	}).Claims(customClaims{Email: emailSubject, EmailVerified: true}).Serialize()
+	if err != nil {
+		t.Fatalf("Serialize() = %v", err)
+	}
+
+	store, err := certstore.NewBoltStore(filepath.Join(t.TempDir(), "certs.db"))
+	if err != nil {
+		t.Fatalf("NewBoltStore() = %v", err)
+	}
+	defer store.Close()
+
+	ctClient, eca := createCA(cfg, t)
+	ctx := context.Background()
+	server, conn := setupGRPCForTest(t, cfg, ctClient, eca, WithCertificateStore(store), WithCertificateLookup())
+	defer func() {
+		server.Stop()
+		conn.Close()
+	}()
+
+	client := protobuf.NewCAClient(conn)
+
+	var issued []string
+	for i := 0; i < 3; i++ {
+		pubBytes, proof := generateKeyAndProof(emailSubject, t)
+		resp, err := client.CreateSigningCertificate(ctx, &protobuf.CreateSigningCertificateRequest{
+			Credentials: &protobuf.Credentials{
+				Credentials: &protobuf.Credentials_OidcIdentityToken{
+					OidcIdentityToken: tok,
+				},
+			},
+			Key: &protobuf.CreateSigningCertificateRequest_PublicKeyRequest{
+				PublicKeyRequest: &protobuf.PublicKeyRequest{
+					PublicKey: &protobuf.PublicKey{
+						Content: pubBytes,
+					},
+					ProofOfPossession: proof,
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("SigningCert() = %v", err)
+		}
+		leafCert := verifyResponse(resp, eca, emailIssuer, t)
+		issued = append(issued, leafCert.SerialNumber.String())
+	}
+
+	got, err := client.GetCertificate(ctx, &protobuf.GetCertificateRequest{SerialNumber: issued[0]})
+	if err != nil {
+		t.Fatalf("GetCertificate() = %v", err)
+	}
+	if got.SerialNumber != issued[0] || got.Issuer != emailIssuer || got.Identity != emailSubject ||
+		len(got.SubjectAlternativeNames) != 1 || got.SubjectAlternativeNames[0] != emailSubject {
+		t.Errorf("unexpected certificate: %v", got)
+	}
+	if len(got.Chain.Certificates) != 2 {
+		t.Errorf("expected certificate and root in chain, got %d certificates", len(got.Chain.Certificates))
+	}
+
+	if _, err := client.GetCertificate(ctx, &protobuf.GetCertificateRequest{SerialNumber: "1"}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected not found for unknown serial, got %v", err)
+	}
+
+	// Page through the certificates issued to the identity
+	var found []string
+	pageToken := ""
+	for {
+		resp, err := client.SearchCertificates(ctx, &protobuf.SearchCertificatesRequest{
+			Identity:  emailSubject,
+			Issuer:    emailIssuer,
+			StartTime: timestamppb.New(time.Now().Add(-time.Hour)),
+			PageSize:  2,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			t.Fatalf("SearchCertificates() = %v", err)
+		}
+		for _, cert := range resp.Certificates {
+			found = append(found, cert.SerialNumber)
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+	if !reflect.DeepEqual(found, issued) {
+		t.Errorf("SearchCertificates() found %v, wanted %v", found, issued)
+	}
+
+	resp, err := client.SearchCertificates(ctx, &protobuf.SearchCertificatesRequest{Identity: "bar@example.com"})
+	if err != nil {
+		t.Fatalf("SearchCertificates() = %v", err)
+	}
+	if len(resp.Certificates) != 0 {
+		t.Errorf("expected no certificates for other identity, got %v", resp.Certificates)
+	}
+
+	if _, err := client.SearchCertificates(ctx, &protobuf.SearchCertificatesRequest{PageToken: "invalid"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected invalid argument for invalid page token, got %v", err)
+	}
+}
+
+// Tests certificate lookup without a certificate store
+func TestAPIWithoutCertificateStore(t *testing.T) {
+	server, conn := setupGRPCForTest(t, &config.FulcioConfig{}, nil, &FailingCertificateAuthority{})
+	defer func() {
+		server.Stop()
+		conn.Close()
+	}()
+
+	client := protobuf.NewCAClient(conn)
+	if _, err := client.GetCertificate(context.Background(), &protobuf.GetCertificateRequest{SerialNumber: "1"}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("expected unimplemented, got %v", err)
+	}
+	if _, err := client.SearchCertificates(context.Background(), &protobuf.SearchCertificatesRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("expected unimplemented, got %v", err)
+	}
+}
+
+// Tests that certificates are recorded, but can't be looked up, unless lookups
+// are enabled
+func TestAPIWithoutCertificateLookup(t *testing.T) {
+	store, err := certstore.NewBoltStore(filepath.Join(t.TempDir(), "certs.db"))
+	if err != nil {
+		t.Fatalf("NewBoltStore() = %v", err)
+	}
+	defer store.Close()
+
+	server, conn := setupGRPCForTest(t, &config.FulcioConfig{}, nil, &FailingCertificateAuthority{}, WithCertificateStore(store))
+	defer func() {
+		server.Stop()
+		conn.Close()
+	}()
+
+	client := protobuf.NewCAClient(conn)
+	for name, call := range map[string]func() error{
+		"GetCertificate": func() error {
+			_, err := client.GetCertificate(context.Background(), &protobuf.GetCertificateRequest{SerialNumber: "1"})
+			return err
+		},
+		"SearchCertificates": func() error {
+			_, err := client.SearchCertificates(context.Background(), &protobuf.SearchCertificatesRequest{})
+			return err
+		},
+	} {
+		err := call()
+		if status.Code(err) != codes.Unimplemented || status.Convert(err).Message() != certificateLookupNotEnabled {
+			t.Errorf("%s: expected lookups to be disabled, got %v", name, err)
+		}
+	}
+}
+
+// Tests API with insecure pub key
+func TestAPIWithInsecurePublicKey(t *testing.T) {
+	emailSigner, emailIssuer := newOIDCIssuer(t)
+
+	// Create a FulcioConfig that supports these issuers.
+	cfg, err := config.Read([]byte(fmt.Sprintf(`{
+		"OIDCIssuers": {
+			%q: {
+				"IssuerURL": %q,
+				"ClientID": "sigstore",
+				"Type": "email"
+			}
+		}
+	}`, emailIssuer, emailIssuer)))
+	if err != nil {
+		t.Fatalf("config.Read() = %v", err)
+	}
+
+	emailSubject := "foo@example.com"
+
+	// Create an OIDC token using this issuer's signer.
+	tok, err := jwt.Signed(emailSigner).Claims(jwt.Claims{
+		Issuer:   emailIssuer,
+		IssuedAt: jwt.NewNumericDate(time.Now()),
+		Expiry:   jwt.NewNumericDate(time.Now().Add(30 * time.Minute)),
+		Subject:  emailSubject,
+		Audience: jwt.Audience{"sigstore"},
+	}).Claims(customClaims{Email: emailSubject, EmailVerified: true}).Serialize()
+	if err != nil {
+		t.Fatalf("Serialize() = %v", err)
+	}
+
+	ctClient, eca := createCA(cfg, t)
+	ctx := context.Background()
+	server, conn := setupGRPCForTest(t, cfg, ctClient, eca)
+	defer func() {
+		server.Stop()
+		conn.Close()
+	}()
+
+	client := protobuf.NewCAClient(conn)
+
+	priv, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("GenerateKey() = %v", err)
+	}
+	pubBytes, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	if err != nil {
+		t.Fatalf("x509.MarshalPKIXPublicKey() = %v", err)
+	}
+
+	// Hit the API to have it sign our certificate.
+	_, err = client.CreateSigningCertificate(ctx, &protobuf.CreateSigningCertificateRequest{
+		Credentials: &protobuf.Credentials{
+			Credentials: &protobuf.Credentials_OidcIdentityToken{
+				OidcIdentityToken: tok,
+			},
+		},
+		Key: &protobuf.CreateSigningCertificateRequest_PublicKeyRequest{
+			PublicKeyRequest: &protobuf.PublicKeyRequest{
+				PublicKey: &protobuf.PublicKey{
+					Content: string(cryptoutils.PEMEncode(cryptoutils.PublicKeyPEMType, pubBytes)),
+				},
+				ProofOfPossession: []byte{},
+			},
+		},
+	})
+	if err == nil || !strings.Contains(err.Error(), "The public key supplied in the request is insecure") {
+		t.Fatalf("expected insecure public key error, got %v", err)
+	}
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected invalid argument, got %v", status.Code(err))
+	}
+}
+
+// Tests API with no public key
+func TestAPIWithoutPublicKey(t *testing.T) {
+	emailSigner, emailIssuer := newOIDCIssuer(t)
+
+	// Create a FulcioConfig that supports these issuers.
+	cfg, err := config.Read([]byte(fmt.Sprintf(`{
+		"OIDCIssuers": {
+			%q: {
+				"IssuerURL": %q,
+				"ClientID": "sigstore",
+				"Type": "email"
+			}
+		}
+	}`, emailIssuer, emailIssuer)))
+	if err != nil {
+		t.Fatalf("config.Read() = %v", err)
+	}
+
+	emailSubject := "foo@example.com"
+
+	// Create an OIDC token using this issuer's signer.
+	tok, err := jwt.Signed(emailSigner).Claims(jwt.Claims{
+		Issuer:   emailIssuer,
+		IssuedAt: jwt.NewNumericDate(time.Now()),
+		Expiry:   jwt.NewNumericDate(time.Now().Add(30 * time.Minute)),
+		Subject:  emailSubject,
+		Audience: jwt.Audience{"sigstore"},
+	}).Claims(customClaims{Email: emailSubject, EmailVerified: true}).Serialize()
+	if err != nil {
+		t.Fatalf("Serialize() = %v", err)
+	}
+
+	ctClient, eca := createCA(cfg, t)
+	ctx := context.Background()
+	server, conn := setupGRPCForTest(t, cfg, ctClient, eca)
+	defer func() {
+		server.Stop()
+		conn.Close()
+	}()
+
+	client := protobuf.NewCAClient(conn)
+
+	// Test with no key proto specified
+	_, err = client.CreateSigningCertificate(ctx, &protobuf.CreateSigningCertificateRequest{
+		Credentials: &protobuf.Credentials{
+			Credentials: &protobuf.Credentials_OidcIdentityToken{
+				OidcIdentityToken: tok,
+			},
+		},
+	})
+	if err == nil || !strings.Contains(err.Error(), "The public key supplied in the request could not be parsed") {
+		t.Fatalf("expected parsing public key error, got %v", err)
+	}
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected invalid argument, got %v", status.Code(err))
+	}
+
+	// Test with no public key specified
+	_, err = client.CreateSigningCertificate(ctx, &protobuf.CreateSigningCertificateRequest{
+		Credentials: &protobuf.Credentials{
+			Credentials: &protobuf.Credentials_OidcIdentityToken{
+				OidcIdentityToken: tok,
+			},
+		},
+		Key: &protobuf.CreateSigningCertificateRequest_PublicKeyRequest{
+			PublicKeyRequest: &protobuf.PublicKeyRequest{},
+		},
+	})
+	if err == nil || !strings.Contains(err.Error(), "The public key supplied in the request could not be parsed") {
+		t.Fatalf("expected parsing public key error, got %v", err)
+	}
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected invalid argument, got %v", status.Code(err))
+	}
+}
+
+// Tests API with invalid challenge as proof of possession of private key
+func TestAPIWithInvalidChallenge(t *testing.T) {
+	emailSigner, emailIssuer := newOIDCIssuer(t)
+
+	// Create a FulcioConfig that supports these issuers.
+	cfg, err := config.Read([]byte(fmt.Sprintf(`{
+		"OIDCIssuers": {
+			%q: {
+				"IssuerURL": %q,
+				"ClientID": "sigstore",
+				"Type": "email"
+			}
+		}
+	}`, emailIssuer, emailIssuer)))
+	if err != nil {
+		t.Fatalf("config.Read() = %v", err)
+	}
+
+	emailSubject := "foo@example.com"
+
+	// Create an OIDC token using this issuer's signer.
+	tok, err := jwt.Signed(emailSigner).Claims(jwt.Claims{
+		Issuer:   emailIssuer,
+		IssuedAt: jwt.NewNumericDate(time.Now()),
+		Expiry:   jwt.NewNumericDate(time.Now().Add(30 * time.Minute)),
+		Subject:  emailSubject,
+		Audience: jwt.Audience{"sigstore"},
+	}).Claims(customClaims{Email: emailSubject, EmailVerified: true}).Serialize()
+	if err != nil {
+		t.Fatalf("Serialize() = %v", err)
+	}
+
+	ctClient, eca := createCA(cfg, t)
+	ctx := context.Background()
+	server, conn := setupGRPCForTest(t, cfg, ctClient, eca)
+	defer func() {
+		server.Stop()
+		conn.Close()
+	}()
+
+	client := protobuf.NewCAClient(conn)
+
+	pubBytes, _ := generateKeyAndProof(emailSubject, t)
+	_, invalidProof := generateKeyAndProof(emailSubject, t)
+
+	_, err = client.CreateSigningCertificate(ctx, &protobuf.CreateSigningCertificateRequest{
+		Credentials: &protobuf.Credentials{
+			Credentials: &protobuf.Credentials_OidcIdentityToken{
+				OidcIdentityToken: tok,
+			},
+		},
+		Key: &protobuf.CreateSigningCertificateRequest_PublicKeyRequest{
+			PublicKeyRequest: &protobuf.PublicKeyRequest{
+				PublicKey: &protobuf.PublicKey{
+					Content: pubBytes,
+				},
+				ProofOfPossession: invalidProof,
+			},
+		},
+	})
+	if err == nil || !strings.Contains(err.Error(), "The signature supplied in the request could not be verified") {
+		t.Fatalf("expected invalid signature error, got %v", err)
+	}
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected invalid argument, got %v", status.Code(err))
+	}
+}
+
+// Tests API with an invalid CSR.
+func TestAPIWithInvalidCSR(t *testing.T) {
+	emailSigner, emailIssuer := newOIDCIssuer(t)
+
+	// Create a FulcioConfig that supports this issuer.
+	cfg, err := config.Read([]byte(fmt.Sprintf(`{
+		"OIDCIssuers": {
+			%q: {
+				"IssuerURL": %q,
+				"ClientID": "sigstore",
+				"Type": "email"
+			}
+		}
+	}`, emailIssuer, emailIssuer)))
+	if err != nil {
+		t.Fatalf("config.Read() = %v", err)
+	}
+
+	emailSubject := "foo@example.com"
+
+	// Create an OIDC token using this issuer's signer.
+	tok, err := jwt.Signed(emailSigner).Claims(jwt.Claims{
+		Issuer:   emailIssuer,
+		IssuedAt: jwt.NewNumericDate(time.Now()),
+		Expiry:   jwt.NewNumericDate(time.Now().Add(30 * time.Minute)),
+		Subject:  emailSubject,
+		Audience: jwt.Audience{"sigstore"},
+	}).Claims(customClaims{Email: emailSubject, EmailVerified: true}).Serialize()
+	if err != nil {
+		t.Fatalf("Serialize() = %v", err)
+	}
+
+	ctClient, eca := createCA(cfg, t)
+	ctx := context.Background()
+	server, conn := setupGRPCForTest(t, cfg, ctClient, eca)
+	defer func() {
+		server.Stop()
+		conn.Close()
+	}()
+
+	client := protobuf.NewCAClient(conn)
+
+	_, err = client.CreateSigningCertificate(ctx, &protobuf.CreateSigningCertificateRequest{
+		Credentials: &protobuf.Credentials{
+			Credentials: &protobuf.Credentials_OidcIdentityToken{
+				OidcIdentityToken: tok,
+			},
+		},
+		Key: &protobuf.CreateSigningCertificateRequest_CertificateSigningRequest{
+			CertificateSigningRequest: []byte("invalid"),
+		},
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "The certificate signing request could not be parsed") {
+		t.Fatalf("expected invalid signature error, got %v", err)
+	}
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected invalid argument, got %v", status.Code(err))
+	}
+}
+
+// Tests API with unsigned CSR, which will fail signature verification.
+func TestAPIWithInvalidCSRSignature(t *testing.T) {
+	emailSigner, emailIssuer := newOIDCIssuer(t)
+
+	// Create a FulcioConfig that supports this issuer.
+	cfg, err := config.Read([]byte(fmt.Sprintf(`{
+		"OIDCIssuers": {
+			%q: {
+				"IssuerURL": %q,
+				"ClientID": "sigstore",
+				"Type": "email"
+			}
+		}
+	}`, emailIssuer, emailIssuer)))
+	if err != nil {
+		t.Fatalf("config.Read() = %v", err)
+	}
+
+	emailSubject := "foo@example.com"
+
+	// Create an OIDC token using this issuer's signer.
+	tok, err := jwt.Signed(emailSigner).Claims(jwt.Claims{
+		Issuer:   emailIssuer,
+		IssuedAt: jwt.NewNumericDate(time.Now()),
+		Expiry:   jwt.NewNumericDate(time.Now().Add(30 * time.Minute)),
+		Subject:  emailSubject,
+		Audience: jwt.Audience{"sigstore"},
+	}).Claims(customClaims{Email: emailSubject, EmailVerified: true}).Serialize()
+	if err != nil {
+		t.Fatalf("Serialize() = %v", err)
+	}
+
+	ctClient, eca := createCA(cfg, t)
+	ctx := context.Background()
+	server, conn := setupGRPCForTest(t, cfg, ctClient, eca)
+	defer func() {
+		server.Stop()
+		conn.Close()
+	}()
+
+	client := protobuf.NewCAClient(conn)
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("error generating private key: %v", err)
+	}
+	csrTmpl := &x509.CertificateRequest{Subject: pkix.Name{CommonName: "test"}}
+	derCSR, err := x509.CreateCertificateRequest(rand.Reader, csrTmpl, priv)
+	if err != nil {
+		t.Fatalf("error creating CSR: %v", err)
 	}
 	// Corrupt signature
 	derCSR[len(derCSR)-1] = derCSR[len(derCSR)-1] + 1
@@ -2325,6 +3504,21 @@ func createCA(_ *config.FulcioConfig, t *testing.T) (*ctclient.LogClient, *ephem
 	return ctClient, eca
 }
 
+// withCTLog submits certificates to ct if it is set, verifying its SCTs if it
+// has a public key, like a single log configured with --ct-log-url.
+func withCTLog(ct *ctclient.LogClient) GRPCCAServerOption {
+	if ct == nil {
+		return func(*grpcaCAServer) {}
+	}
+	var l ctl.Log = ct
+	if verifying, err := ctl.WithSCTVerification(ct); err == nil {
+		l = verifying
+	}
+	// A single log can't fail to reach a quorum of 1
+	logs, _ := ctl.NewLogs(1, l)
+	return WithCTLogs(logs)
+}
+
 // generateKeyAndProof creates a public key to be certified and creates a
 // signature for the OIDC token subject
 func generateKeyAndProof(subject string, t *testing.T) (string, []byte) {
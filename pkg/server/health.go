@@ -0,0 +1,314 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package server
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	certauth "github.com/sigstore/fulcio/pkg/ca"
+	"github.com/sigstore/fulcio/pkg/ctl"
+	fulciogrpc "github.com/sigstore/fulcio/pkg/generated/protobuf"
+	"github.com/sigstore/fulcio/pkg/log"
+)
+
+const (
+	// HealthServiceCA reports whether the certificate authority is available,
+	// and whether it can sign if WithCASignatureCheck is set.
+	HealthServiceCA = "fulcio.ca"
+	// HealthServiceCTLog reports whether the CT log is reachable.
+	HealthServiceCTLog = "fulcio.ctlog"
+
+	healthServiceOIDCPrefix = "fulcio.oidc/"
+)
+
+// HealthServiceOIDC returns the health service name reporting whether OIDC
+// discovery succeeds for the given issuer URL.
+func HealthServiceOIDC(issuerURL string) string {
+	return healthServiceOIDCPrefix + issuerURL
+}
+
+// HealthChecker periodically probes the dependencies required to issue
+// certificates and publishes their status through the gRPC health service.
+//
+// The overall status (the empty service name, and the name of the CA service)
+// is SERVING only when both the CA and a quorum of the CT logs are healthy.
+// OIDC issuers are reported individually but do not affect the overall
+// status, since an outage of a single external identity provider affects
+// every replica equally and should not take the whole deployment out of
+// rotation.
+type HealthChecker struct {
+	*health.Server
+
+	ca       certauth.CertificateAuthority
+	logs     *ctl.Logs
+	cfg      *ConfigReloader
+	interval time.Duration
+	timeout  time.Duration
+	// caSignature is set if the CA is probed with a test signature
+	caSignature bool
+
+	// last and failedLogs are only accessed by the probing goroutine
+	last       map[string]healthpb.HealthCheckResponse_ServingStatus
+	failedLogs map[int]bool
+}
+
+// HealthCheckerOption configures a HealthChecker.
+type HealthCheckerOption func(*HealthChecker)
+
+// WithCASignatureCheck probes CAs that expose their signer by creating a test
+// signature, so that an unavailable KMS or HSM is detected. Each probe is a
+// signing operation, which counts against the quota of the KMS or HSM and may
+// be billed.
+func WithCASignatureCheck() HealthCheckerOption {
+	return func(h *HealthChecker) {
+		h.caSignature = true
+	}
+}
+
+// NewHealthChecker creates a HealthChecker for the given dependencies. logs
+// may be nil if CT log submission is disabled. The OIDC issuers to probe are
+// read from the current configuration of cfg on every round of probes. Every
+// service reports NOT_SERVING until the first round of probes has completed.
+func NewHealthChecker(logs *ctl.Logs, ca certauth.CertificateAuthority, cfg *ConfigReloader, interval, timeout time.Duration, opts ...HealthCheckerOption) *HealthChecker {
+	h := &HealthChecker{
+		Server:     health.NewServer(),
+		ca:         ca,
+		logs:       logs,
+		cfg:        cfg,
+		interval:   interval,
+		timeout:    timeout,
+		last:       map[string]healthpb.HealthCheckResponse_ServingStatus{},
+		failedLogs: map[int]bool{},
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	services := []string{"", fulciogrpc.CA_ServiceDesc.ServiceName, HealthServiceCA}
+	if logs != nil {
+		services = append(services, HealthServiceCTLog)
+	}
+	for _, issuerURL := range h.issuers() {
+		services = append(services, HealthServiceOIDC(issuerURL))
+	}
+	for _, service := range services {
+		h.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+		h.last[service] = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	return h
+}
+
+// issuers returns the sorted URLs of the OIDC issuers in the current
+// configuration.
+func (h *HealthChecker) issuers() []string {
+	cfg := h.cfg.Config()
+	if cfg == nil {
+		return nil
+	}
+	issuers := make([]string, 0, len(cfg.OIDCIssuers))
+	for issuerURL := range cfg.OIDCIssuers {
+		issuers = append(issuers, issuerURL)
+	}
+	sort.Strings(issuers)
+	return issuers
+}
+
+// Start probes the dependencies immediately and then once per interval until
+// ctx is cancelled, at which point all services are reported as NOT_SERVING.
+func (h *HealthChecker) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(h.interval)
+		defer ticker.Stop()
+		for {
+			h.probe(ctx)
+			select {
+			case <-ctx.Done():
+				h.Shutdown()
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (h *HealthChecker) probe(ctx context.Context) {
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = map[string]error{}
+	)
+	run := func(service string, check func(context.Context) error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, h.timeout)
+			defer cancel()
+			err := check(ctx)
+			mu.Lock()
+			results[service] = err
+			mu.Unlock()
+		}()
+	}
+
+	run(HealthServiceCA, h.checkCA)
+	if h.logs != nil {
+		run(HealthServiceCTLog, h.checkCTLogs)
+	}
+	issuers := h.issuers()
+	for _, issuerURL := range issuers {
+		run(HealthServiceOIDC(issuerURL), func(ctx context.Context) error {
+			_, err := oidc.NewProvider(ctx, issuerURL)
+			return err
+		})
+	}
+	wg.Wait()
+
+	// Issuers removed by a configuration reload are no longer reported
+	for service := range h.last {
+		if _, ok := results[service]; !ok && strings.HasPrefix(service, healthServiceOIDCPrefix) {
+			h.SetServingStatus(service, healthpb.HealthCheckResponse_SERVICE_UNKNOWN)
+			delete(h.last, service)
+		}
+	}
+
+	overall := healthpb.HealthCheckResponse_SERVING
+	for service, err := range results {
+		h.setStatus(service, err)
+		if err != nil && !strings.HasPrefix(service, healthServiceOIDCPrefix) {
+			overall = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+	}
+	for _, service := range []string{"", fulciogrpc.CA_ServiceDesc.ServiceName} {
+		h.SetServingStatus(service, overall)
+	}
+}
+
+func (h *HealthChecker) setStatus(service string, err error) {
+	status := healthpb.HealthCheckResponse_SERVING
+	if err != nil {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	if prev := h.last[service]; prev != status {
+		if err != nil {
+			log.Logger.Errorw("health check failed", "service", service, "error", err)
+		} else {
+			log.Logger.Infow("health check recovered", "service", service, "previous", prev.String())
+		}
+		h.last[service] = status
+	}
+	h.SetServingStatus(service, status)
+}
+
+// checkCA fetches the trust bundle and checks that the issuing certificate
+// has not expired. With WithCASignatureCheck, CAs that expose their signer
+// also create a test signature.
+func (h *HealthChecker) checkCA(ctx context.Context) error {
+	trustBundle, err := h.ca.TrustBundle(ctx)
+	if err != nil {
+		return fmt.Errorf("retrieving trust bundle: %w", err)
+	}
+	if len(trustBundle) == 0 || len(trustBundle[0]) == 0 {
+		return errors.New("trust bundle is empty")
+	}
+	if time.Now().After(trustBundle[0][0].NotAfter) {
+		return fmt.Errorf("issuing certificate expired at %v", trustBundle[0][0].NotAfter)
+	}
+
+	if !h.caSignature {
+		return nil
+	}
+	swc, ok := h.ca.(certauth.SignerWithChain)
+	if !ok {
+		return nil
+	}
+	_, signer := swc.GetSignerWithChain()
+	if signer == nil {
+		return errors.New("no signer loaded")
+	}
+	msg := []byte("fulcio health check")
+	if _, ok := signer.Public().(ed25519.PublicKey); ok {
+		_, err = signer.Sign(rand.Reader, msg, crypto.Hash(0))
+	} else {
+		digest := sha256.Sum256(msg)
+		_, err = signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	}
+	if err != nil {
+		return fmt.Errorf("creating test signature: %w", err)
+	}
+	return nil
+}
+
+// checkCTLogs probes every CT log, and fails if fewer than the quorum of
+// logs are reachable, since certificates can't be issued without their SCTs.
+func (h *HealthChecker) checkCTLogs(ctx context.Context) error {
+	members := h.logs.Members()
+	errs := make([]error, len(members))
+	var wg sync.WaitGroup
+	for i, member := range members {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = ctl.CheckHealth(ctx, member)
+		}()
+	}
+	wg.Wait()
+
+	var (
+		healthy int
+		failed  []error
+	)
+	for i, err := range errs {
+		name := ctLogName(i, members[i])
+		if err != nil {
+			failed = append(failed, fmt.Errorf("%s: %w", name, err))
+			if !h.failedLogs[i] {
+				log.Logger.Warnw("CT log health check failed", "log", name, "error", err)
+			}
+		} else {
+			healthy++
+			if h.failedLogs[i] {
+				log.Logger.Infow("CT log health check recovered", "log", name)
+			}
+		}
+		h.failedLogs[i] = err != nil
+	}
+	if healthy < h.logs.Quorum() {
+		return fmt.Errorf("%d of %d CT logs reachable, quorum is %d: %w", healthy, len(members), h.logs.Quorum(), errors.Join(failed...))
+	}
+	return nil
+}
+
+// ctLogName names the i-th CT log in logs by its URL if it has one.
+func ctLogName(i int, l ctl.Log) string {
+	if clients := ctl.LogClients(l); len(clients) > 0 {
+		return clients[0].BaseURI()
+	}
+	return fmt.Sprintf("CT log %d", i)
+}
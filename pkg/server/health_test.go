@@ -0,0 +1,316 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ctclient "github.com/google/certificate-transparency-go/client"
+	"github.com/google/certificate-transparency-go/jsonclient"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/sigstore/fulcio/pkg/ca/ephemeralca"
+	"github.com/sigstore/fulcio/pkg/config"
+	"github.com/sigstore/fulcio/pkg/ctl"
+	"github.com/sigstore/fulcio/pkg/generated/protobuf"
+)
+
+// fakeSTHServer serves a get-sth response, or a 503 when unhealthy is set.
+func fakeSTHServer(t *testing.T, unhealthy *atomic.Bool) *ctclient.LogClient {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		if unhealthy.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{
+			"tree_size":1,
+			"timestamp":1337,
+			"sha256_root_hash":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=",
+			"tree_head_signature":"BAMARjBEAiAIc21J5ZbdKZHw5wLxCP+MhBEsV5+nfvGyakOIv6FOvAIgWYMZb6Pw///uiNM7QTg2Of1OqmK1GbeGuEl9VJN8v8c="
+		}`)
+	}))
+	t.Cleanup(srv.Close)
+	ct, err := ctclient.New(srv.URL, &http.Client{Timeout: 5 * time.Second}, jsonclient.Options{})
+	if err != nil {
+		t.Fatalf("ctclient.New() = %v", err)
+	}
+	return ct
+}
+
+func TestHealthCheckerStatus(t *testing.T) {
+	_, issuerURL := newOIDCIssuer(t)
+	cfg, err := config.Read([]byte(fmt.Sprintf(`{
+		"OIDCIssuers": {
+			%q: {
+				"IssuerURL": %q,
+				"ClientID": "sigstore",
+				"Type": "email"
+			},
+			"http://127.0.0.1:1/unreachable": {
+				"IssuerURL": "http://127.0.0.1:1/unreachable",
+				"ClientID": "sigstore",
+				"Type": "email"
+			}
+		}
+	}`, issuerURL, issuerURL)))
+	if err != nil {
+		t.Fatalf("config.Read() = %v", err)
+	}
+	eca, err := ephemeralca.NewEphemeralCA()
+	if err != nil {
+		t.Fatalf("ephemeralca.NewEphemeralCA() = %v", err)
+	}
+	// One of the two logs is enough for the quorum
+	var unhealthy, otherUnhealthy atomic.Bool
+	logs, err := ctl.NewLogs(1, fakeSTHServer(t, &unhealthy), fakeSTHServer(t, &otherUnhealthy))
+	if err != nil {
+		t.Fatalf("NewLogs() = %v", err)
+	}
+
+	h := NewHealthChecker(logs, eca, NewConfigReloader("", cfg, NewIssuerPool), time.Hour, 5*time.Second)
+	ctx := context.Background()
+
+	check := func(service string, want healthpb.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		resp, err := h.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatalf("Check(%q) = %v", service, err)
+		}
+		if resp.Status != want {
+			t.Errorf("Check(%q) = %v, wanted %v", service, resp.Status, want)
+		}
+	}
+
+	// Nothing has been probed yet
+	check("", healthpb.HealthCheckResponse_NOT_SERVING)
+	check(HealthServiceCA, healthpb.HealthCheckResponse_NOT_SERVING)
+
+	h.probe(ctx)
+	check("", healthpb.HealthCheckResponse_SERVING)
+	check(protobuf.CA_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	check(HealthServiceCA, healthpb.HealthCheckResponse_SERVING)
+	check(HealthServiceCTLog, healthpb.HealthCheckResponse_SERVING)
+	check(HealthServiceOIDC(issuerURL), healthpb.HealthCheckResponse_SERVING)
+	// An unreachable issuer is reported but does not affect overall health
+	check(HealthServiceOIDC("http://127.0.0.1:1/unreachable"), healthpb.HealthCheckResponse_NOT_SERVING)
+
+	unhealthy.Store(true)
+	h.probe(ctx)
+	check("", healthpb.HealthCheckResponse_SERVING)
+	check(HealthServiceCTLog, healthpb.HealthCheckResponse_SERVING)
+
+	// Without a quorum of logs, certificates can't be issued
+	otherUnhealthy.Store(true)
+	h.probe(ctx)
+	check("", healthpb.HealthCheckResponse_NOT_SERVING)
+	check(HealthServiceCTLog, healthpb.HealthCheckResponse_NOT_SERVING)
+	check(HealthServiceCA, healthpb.HealthCheckResponse_SERVING)
+
+	unhealthy.Store(false)
+	h.probe(ctx)
+	check("", healthpb.HealthCheckResponse_SERVING)
+}
+
+func TestHealthCheckerReload(t *testing.T) {
+	_, issuerURL := newOIDCIssuer(t)
+	_, otherIssuerURL := newOIDCIssuer(t)
+	eca, err := ephemeralca.NewEphemeralCA()
+	if err != nil {
+		t.Fatalf("ephemeralca.NewEphemeralCA() = %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	writeConfig(t, path, emailIssuerConfig(issuerURL))
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("config.Load() = %v", err)
+	}
+	r := NewConfigReloader(path, cfg, NewIssuerPool)
+	h := NewHealthChecker(nil, eca, r, time.Hour, 5*time.Second)
+	ctx := context.Background()
+
+	check := func(service string, want healthpb.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		resp, err := h.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatalf("Check(%q) = %v", service, err)
+		}
+		if resp.Status != want {
+			t.Errorf("Check(%q) = %v, wanted %v", service, resp.Status, want)
+		}
+	}
+
+	h.probe(ctx)
+	check(HealthServiceOIDC(issuerURL), healthpb.HealthCheckResponse_SERVING)
+
+	// Issuers added by a reload are probed, and removed ones are no longer
+	// reported
+	writeConfig(t, path, emailIssuerConfig(otherIssuerURL))
+	if err := r.Reload(); err != nil {
+		t.Fatalf("Reload() = %v", err)
+	}
+	h.probe(ctx)
+	check(HealthServiceOIDC(otherIssuerURL), healthpb.HealthCheckResponse_SERVING)
+	check(HealthServiceOIDC(issuerURL), healthpb.HealthCheckResponse_SERVICE_UNKNOWN)
+}
+
+// failingSignerCA is a CA whose signer is unavailable
+type failingSignerCA struct {
+	*ephemeralca.EphemeralCA
+}
+
+func (ca failingSignerCA) GetSignerWithChain() ([]*x509.Certificate, crypto.Signer) {
+	certs, signer := ca.EphemeralCA.GetSignerWithChain()
+	return certs, failingSigner{signer}
+}
+
+type failingSigner struct {
+	crypto.Signer
+}
+
+func (failingSigner) Sign(io.Reader, []byte, crypto.SignerOpts) ([]byte, error) {
+	return nil, errors.New("KMS unavailable")
+}
+
+func TestHealthCheckerCASignature(t *testing.T) {
+	eca, err := ephemeralca.NewEphemeralCA()
+	if err != nil {
+		t.Fatalf("ephemeralca.NewEphemeralCA() = %v", err)
+	}
+	ca := failingSignerCA{eca}
+
+	for _, tc := range []struct {
+		opts []HealthCheckerOption
+		want healthpb.HealthCheckResponse_ServingStatus
+	}{
+		// Test signatures are opt-in, since they cost KMS or HSM quota
+		{nil, healthpb.HealthCheckResponse_SERVING},
+		{[]HealthCheckerOption{WithCASignatureCheck()}, healthpb.HealthCheckResponse_NOT_SERVING},
+	} {
+		h := NewHealthChecker(nil, ca, nil, time.Hour, 5*time.Second, tc.opts...)
+		h.probe(context.Background())
+		resp, err := h.Check(context.Background(), &healthpb.HealthCheckRequest{Service: HealthServiceCA})
+		if err != nil {
+			t.Fatalf("Check() = %v", err)
+		}
+		if resp.Status != tc.want {
+			t.Errorf("with %d options, Check() = %v, wanted %v", len(tc.opts), resp.Status, tc.want)
+		}
+	}
+}
+
+func TestHealthCheckerFailingCA(t *testing.T) {
+	h := NewHealthChecker(nil, &FailingCertificateAuthority{}, nil, time.Hour, 5*time.Second)
+	h.probe(context.Background())
+
+	for _, service := range []string{"", HealthServiceCA} {
+		resp, err := h.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatalf("Check(%q) = %v", service, err)
+		}
+		if resp.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+			t.Errorf("Check(%q) = %v, wanted NOT_SERVING", service, resp.Status)
+		}
+	}
+	// CT log is not configured, so there is no status for it
+	if _, err := h.Check(context.Background(), &healthpb.HealthCheckRequest{Service: HealthServiceCTLog}); err == nil {
+		t.Error("expected error checking unconfigured CT log")
+	}
+}
+
+func TestHealthWatch(t *testing.T) {
+	eca, err := ephemeralca.NewEphemeralCA()
+	if err != nil {
+		t.Fatalf("ephemeralca.NewEphemeralCA() = %v", err)
+	}
+	var unhealthy atomic.Bool
+	ct := fakeSTHServer(t, &unhealthy)
+	logs, err := ctl.NewLogs(1, ct)
+	if err != nil {
+		t.Fatalf("NewLogs() = %v", err)
+	}
+	h := NewHealthChecker(logs, eca, NewConfigReloader("", &config.FulcioConfig{}, NewIssuerPool), time.Hour, 5*time.Second)
+
+	listener := bufconn.Listen(bufSize)
+	s := grpc.NewServer()
+	healthpb.RegisterHealthServer(s, NewGRPCCAServer(eca, nil, withCTLog(ct), WithHealthChecker(h)))
+	go func() {
+		if err := s.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			t.Errorf("Server exited with error: %v", err)
+		}
+	}()
+	defer s.Stop()
+
+	conn, err := grpc.NewClient("passthrough",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.NewClient() = %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	stream, err := healthpb.NewHealthClient(conn).Watch(ctx, &healthpb.HealthCheckRequest{Service: HealthServiceCTLog})
+	if err != nil {
+		t.Fatalf("Watch() = %v", err)
+	}
+
+	expect := func(want healthpb.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv() = %v", err)
+		}
+		if resp.Status != want {
+			t.Fatalf("got status %v, wanted %v", resp.Status, want)
+		}
+	}
+
+	expect(healthpb.HealthCheckResponse_NOT_SERVING)
+	h.probe(ctx)
+	expect(healthpb.HealthCheckResponse_SERVING)
+	unhealthy.Store(true)
+	h.probe(ctx)
+	expect(healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+func TestHealthWithoutChecker(t *testing.T) {
+	g := NewGRPCCAServer(&FailingCertificateAuthority{}, nil)
+	resp, err := g.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Check() = %v", err)
+	}
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("got status %v, wanted SERVING", resp.Status)
+	}
+}
@@ -75,7 +75,7 @@ func (l *legacyGRPCCAServer) CreateSigningCertificate(ctx context.Context, reque
 	} else {
 		// the CSR and the public key have not been set
 		if request.PublicKey == nil {
-			return nil, handleFulcioGRPCError(ctx, codes.InvalidArgument, errors.New("public key not provided"), invalidPublicKey)
+			return nil, handleFulcioGRPCError(ctx, codes.InvalidArgument, ReasonInvalidPublicKey, errors.New("public key not provided"), invalidPublicKey)
 		}
 		// create new CA request mapping fields from legacy to actual
 		algorithmEnum, ok := fulciogrpc.PublicKeyAlgorithm_value[strings.ToUpper(request.PublicKey.Algorithm)] //lint:ignore SA1019 this is valid because we're converting from v1beta to v1 API
@@ -27,6 +27,26 @@ var (
 		Help: "The total number of certificates generated",
 	})
 
+	metricCertificateStoreErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "fulcio_certificate_store_errors",
+		Help: "The total number of issued certificates that could not be stored",
+	})
+
+	metricRateLimited = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "fulcio_rate_limited_requests",
+		Help: "The total number of certificate requests rejected by rate limits",
+	}, []string{"scope"})
+
+	metricConfigReloads = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "fulcio_config_reloads",
+		Help: "The total number of attempts to reload the configuration after it changed, by result",
+	}, []string{"result"})
+
+	metricConfigLastReloadSuccess = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "fulcio_config_last_reload_success_timestamp_seconds",
+		Help: "The time the configuration was last reloaded successfully",
+	})
+
 	MetricLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
 		Name: "fulcio_api_latency",
 		Help: "API Latency on calls",
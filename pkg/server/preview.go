@@ -0,0 +1,146 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package server
+
+import (
+	"context"
+	"crypto/x509"
+	"net"
+
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	certauth "github.com/sigstore/fulcio/pkg/ca"
+	"github.com/sigstore/fulcio/pkg/certificate"
+	fulciogrpc "github.com/sigstore/fulcio/pkg/generated/protobuf"
+	"github.com/sigstore/fulcio/pkg/log"
+)
+
+var keyUsageNames = []struct {
+	usage x509.KeyUsage
+	name  string
+}{
+	{x509.KeyUsageDigitalSignature, "DigitalSignature"},
+	{x509.KeyUsageContentCommitment, "ContentCommitment"},
+	{x509.KeyUsageKeyEncipherment, "KeyEncipherment"},
+	{x509.KeyUsageDataEncipherment, "DataEncipherment"},
+	{x509.KeyUsageKeyAgreement, "KeyAgreement"},
+	{x509.KeyUsageCertSign, "CertSign"},
+	{x509.KeyUsageCRLSign, "CRLSign"},
+	{x509.KeyUsageEncipherOnly, "EncipherOnly"},
+	{x509.KeyUsageDecipherOnly, "DecipherOnly"},
+}
+
+var extKeyUsageNames = map[x509.ExtKeyUsage]string{
+	x509.ExtKeyUsageAny:             "Any",
+	x509.ExtKeyUsageServerAuth:      "ServerAuth",
+	x509.ExtKeyUsageClientAuth:      "ClientAuth",
+	x509.ExtKeyUsageCodeSigning:     "CodeSigning",
+	x509.ExtKeyUsageEmailProtection: "EmailProtection",
+	x509.ExtKeyUsageTimeStamping:    "TimeStamping",
+	x509.ExtKeyUsageOCSPSigning:     "OCSPSigning",
+}
+
+// PreviewSigningCertificate authenticates the request, checks the issuer and
+// key policies, and returns the contents of the certificate that would be
+// issued for it, without calling the CA, the CT log or the authorization
+// webhook. Single-use tokens are not used up, so the same token can then be
+// used to request the certificate.
+func (g *grpcaCAServer) PreviewSigningCertificate(ctx context.Context, request *fulciogrpc.CreateSigningCertificateRequest) (*fulciogrpc.CertificatePreview, error) {
+	ctx = withRequestIssuer(ctx, requestToken(ctx, request.Credentials))
+	principal, issuerURL, err := g.verifyCredentials(ctx, request.Credentials)
+	if err != nil {
+		return nil, err
+	}
+	ctx, err = withValidity(ctx, issuerURL, request.RequestedValidity)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, _, err := g.verifyPublicKey(ctx, principal, issuerURL, request.GetCertificateSigningRequest(), request.GetPublicKeyRequest())
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := certauth.MakeX509(ctx, principal, publicKey)
+	if err != nil {
+		// Errors embedding the principal are caused by the token's claims
+		if _, ok := err.(certauth.ValidationError); ok {
+			return nil, handleFulcioGRPCError(ctx, codes.InvalidArgument, ReasonInvalidClaims, err, err.Error())
+		}
+		return nil, handleFulcioGRPCError(ctx, codes.Internal, ReasonCAError, err, genericCAError)
+	}
+	return certificatePreview(ctx, issuerURL, principal.Name(ctx), cert), nil
+}
+
+// certificatePreview describes the certificate template cert.
+func certificatePreview(ctx context.Context, issuerURL, name string, cert *x509.Certificate) *fulciogrpc.CertificatePreview {
+	preview := &fulciogrpc.CertificatePreview{
+		Issuer:    issuerURL,
+		Identity:  name,
+		NotBefore: timestamppb.New(cert.NotBefore),
+		NotAfter:  timestamppb.New(cert.NotAfter),
+	}
+
+	preview.SubjectAlternativeNames = subjectAlternativeNames(cert)
+
+	for _, ku := range keyUsageNames {
+		if cert.KeyUsage&ku.usage != 0 {
+			preview.KeyUsages = append(preview.KeyUsages, ku.name)
+		}
+	}
+	for _, eku := range cert.ExtKeyUsage {
+		if name, ok := extKeyUsageNames[eku]; ok {
+			preview.ExtendedKeyUsages = append(preview.ExtendedKeyUsages, name)
+		}
+	}
+
+	for _, ext := range cert.ExtraExtensions {
+		e := &fulciogrpc.CertificateExtension{
+			Oid:      ext.Id.String(),
+			Name:     certificate.ExtensionName(ext.Id),
+			Critical: ext.Critical,
+			RawValue: ext.Value,
+		}
+		if e.Name != "" {
+			// An invalid value is still shown as the raw value
+			value, err := certificate.ExtensionValue(ext)
+			if err != nil {
+				log.ContextLogger(ctx).Warnw("failed to decode extension", "oid", e.Oid, "error", err)
+			}
+			e.Value = value
+		}
+		preview.Extensions = append(preview.Extensions, e)
+	}
+	return preview
+}
+
+// subjectAlternativeNames returns the SANs of the certificate template cert.
+func subjectAlternativeNames(cert *x509.Certificate) []string {
+	var sans []string
+	sans = append(sans, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		sans = append(sans, uri.String())
+	}
+	sans = append(sans, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, net.IP(ip).String())
+	}
+	if otherName, err := cryptoutils.UnmarshalOtherNameSAN(cert.ExtraExtensions); err == nil {
+		sans = append(sans, otherName)
+	}
+	return sans
+}
@@ -0,0 +1,314 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/sigstore/fulcio/pkg/audit"
+	"github.com/sigstore/fulcio/pkg/config"
+	fulciogrpc "github.com/sigstore/fulcio/pkg/generated/protobuf"
+	"github.com/sigstore/fulcio/pkg/generated/protobuf/legacy"
+	"github.com/sigstore/fulcio/pkg/identity"
+	"github.com/sigstore/fulcio/pkg/log"
+)
+
+// RetryAfterMetadataKey is the response header set on rate limited requests
+// with the number of seconds to wait before retrying.
+const RetryAfterMetadataKey = "retry-after"
+
+// maxRateLimiters bounds the number of token buckets kept in memory. The least
+// recently used bucket is evicted first, which resets its limit.
+const maxRateLimiters = 100000
+
+// RateLimiter limits the rate at which certificates are issued to each
+// identity and for each OIDC or client certificate issuer, using the limits
+// configured per issuer.
+type RateLimiter struct {
+	ip identity.IssuerPool
+	// clientCerts is nil unless client certificate credentials are accepted
+	clientCerts *ClientCertificateVerifier
+	// audit is nil unless rejected requests are audited
+	audit *audit.Logger
+
+	mu       sync.Mutex
+	limiters *lru.Cache
+}
+
+// RateLimiterOption configures a RateLimiter.
+type RateLimiterOption func(*RateLimiter)
+
+// WithRateLimiterAuditLogger records an audit event for every rate limited
+// certificate request, which never reaches the handler.
+func WithRateLimiterAuditLogger(l *audit.Logger) RateLimiterOption {
+	return func(r *RateLimiter) {
+		r.audit = l
+	}
+}
+
+// WithRateLimiterClientCertificates limits requests authenticated by client
+// certificates verified by v, with the limits of their issuer.
+func WithRateLimiterClientCertificates(v *ClientCertificateVerifier) RateLimiterOption {
+	return func(r *RateLimiter) {
+		r.clientCerts = v
+	}
+}
+
+// NewRateLimiter returns a RateLimiter that authenticates tokens with ip.
+func NewRateLimiter(ip identity.IssuerPool, opts ...RateLimiterOption) *RateLimiter {
+	limiters, _ := lru.New(maxRateLimiters) // only errors on a non-positive size
+	r := &RateLimiter{
+		ip:       ip,
+		limiters: limiters,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// UnaryServerInterceptor rejects certificate requests that exceed the rate
+// limits of the issuer of the token or client certificate with
+// codes.ResourceExhausted, which the HTTP gateway maps to 429 Too Many
+// Requests. The time to wait before retrying is set in the
+// RetryAfterMetadataKey header and as RetryInfo in the status.
+//
+// Requests that can't be authenticated are passed through unlimited, so that
+// the handler reports the authentication failure. The authenticated principal
+// is kept in the context so that the handler doesn't verify the token again.
+// Single-use tokens are not used up by the interceptor, so that a request that
+// is rate limited can be retried with the same token.
+// The interceptor must run after the FulcioConfig is added to the context.
+func (r *RateLimiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		// Previews share the request message but don't issue a certificate
+		if info.FullMethod == fulciogrpc.CA_PreviewSigningCertificate_FullMethodName {
+			return handler(ctx, req)
+		}
+		n, clientCert, token := issuanceRequest(ctx, req)
+		if n == 0 {
+			return handler(ctx, req)
+		}
+		var (
+			principal identity.Principal
+			issuerURL string
+			rl        *config.RateLimit
+		)
+		if clientCert {
+			principal, issuerURL, rl = r.clientCertificateLimit(ctx)
+		} else {
+			ctx, principal, issuerURL, rl = r.tokenLimit(ctx, token)
+		}
+		if rl == nil {
+			return handler(ctx, req)
+		}
+
+		if err := r.allow(ctx, issuerURL, principal.Name(ctx), rl, n); err != nil {
+			event := newAuditEvent(ctx, principal, issuerURL)
+			setAuditOutcome(event, err)
+			r.audit.Record(ctx, event)
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// tokenLimit returns the principal of token, the URL of its issuer and the
+// rate limits of the issuer, or nil limits if the token can't be
+// authenticated or its issuer is unlimited. The returned context records the
+// authenticated principal.
+func (r *RateLimiter) tokenLimit(ctx context.Context, token string) (context.Context, identity.Principal, string, *config.RateLimit) {
+	if token == "" {
+		return ctx, nil, "", nil
+	}
+	issuerURL, err := identity.ExtractIssuerURL(token)
+	if err != nil {
+		return ctx, nil, "", nil
+	}
+	ctx = withRequestIssuer(ctx, token)
+	cfg := config.FromContext(ctx)
+	if cfg == nil {
+		return ctx, nil, "", nil
+	}
+	iss, ok := cfg.GetIssuer(issuerURL)
+	if !ok || iss.RateLimit == nil {
+		return ctx, nil, "", nil
+	}
+	principal, err := issuerPoolFromContext(ctx, r.ip).Verify(ctx, token)
+	if err != nil {
+		return ctx, nil, "", nil
+	}
+	return withAuthenticated(ctx, token, principal), principal, issuerURL, iss.RateLimit
+}
+
+// clientCertificateLimit returns the principal of the client certificate of
+// the connection, the URL of its issuer and the rate limits of the issuer, or
+// nil limits if the certificate can't be authenticated or its issuer is
+// unlimited.
+func (r *RateLimiter) clientCertificateLimit(ctx context.Context) (identity.Principal, string, *config.RateLimit) {
+	cfg := config.FromContext(ctx)
+	if r.clientCerts == nil || cfg == nil {
+		return nil, "", nil
+	}
+	principal, issuerURL, err := r.clientCerts.Authenticate(ctx)
+	if err != nil {
+		return nil, "", nil
+	}
+	iss, ok := cfg.ClientCertificateIssuers[issuerURL]
+	if !ok || iss.RateLimit == nil {
+		return nil, "", nil
+	}
+	return principal, issuerURL, iss.RateLimit
+}
+
+// issuanceRequest returns the number of certificates requested by req, whether
+// it is authenticated by a client certificate, and otherwise its OIDC token.
+func issuanceRequest(ctx context.Context, req interface{}) (int, bool, string) {
+	var (
+		n           int
+		credentials *fulciogrpc.Credentials
+	)
+	switch req := req.(type) {
+	case *fulciogrpc.CreateSigningCertificateRequest:
+		n, credentials = 1, req.GetCredentials()
+	case *fulciogrpc.CreateSigningCertificatesRequest:
+		n, credentials = len(req.Keys), req.GetCredentials()
+		if n > maxKeysPerRequest {
+			// The handler rejects the request without issuing anything
+			n = 0
+		}
+	case *legacy.CreateSigningCertificateRequest:
+		n = 1
+	}
+	if credentials.GetClientCertificate() != nil {
+		return n, true, ""
+	}
+	return n, false, requestToken(ctx, credentials)
+}
+
+func (r *RateLimiter) allow(ctx context.Context, issuerURL, name string, rl *config.RateLimit, n int) error {
+	now := time.Now()
+	var reservations []*rate.Reservation
+	cancel := func() {
+		for _, res := range reservations {
+			res.CancelAt(now)
+		}
+	}
+
+	for _, bucket := range []struct {
+		scope     string
+		key       string
+		perMinute float64
+		burst     int
+		reason    string
+		message   string
+	}{
+		{"identity", issuerURL + "\x00" + name, rl.IdentityPerMinute, rl.IdentityBurst, ReasonIdentityRateLimited, identityRateLimitExceeded},
+		{"issuer", issuerURL, rl.IssuerPerMinute, rl.IssuerBurst, ReasonIssuerRateLimited, issuerRateLimitExceeded},
+	} {
+		if bucket.perMinute == 0 {
+			continue
+		}
+		res := r.limiter(bucket.scope+"\x00"+bucket.key, bucket.perMinute, bucket.burst).ReserveN(now, n)
+		if !res.OK() {
+			cancel()
+			metricRateLimited.WithLabelValues(bucket.scope).Inc()
+			err := fmt.Errorf("%d certificates requested for %s exceeds %s burst", n, name, bucket.scope)
+			return handleFulcioGRPCError(ctx, codes.ResourceExhausted, bucket.reason, err, bucket.message)
+		}
+		reservations = append(reservations, res)
+		if delay := res.DelayFrom(now); delay > 0 {
+			cancel()
+			metricRateLimited.WithLabelValues(bucket.scope).Inc()
+			return rateLimitedError(ctx, name, bucket.scope, bucket.reason, bucket.message, delay)
+		}
+	}
+	return nil
+}
+
+// limiter returns the token bucket for key, updating its limits if the
+// configuration has changed.
+func (r *RateLimiter) limiter(key string, perMinute float64, burst int) *rate.Limiter {
+	limit := rate.Limit(perMinute / 60)
+	if burst == 0 {
+		burst = int(math.Max(1, math.Ceil(perMinute)))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cached, ok := r.limiters.Get(key); ok {
+		lim := cached.(*rate.Limiter)
+		if lim.Limit() != limit {
+			lim.SetLimit(limit)
+		}
+		if lim.Burst() != burst {
+			lim.SetBurst(burst)
+		}
+		return lim
+	}
+	lim := rate.NewLimiter(limit, burst)
+	r.limiters.Add(key, lim)
+	return lim
+}
+
+func rateLimitedError(ctx context.Context, name, scope, reason, message string, delay time.Duration) error {
+	retryAfter := int(math.Ceil(delay.Seconds()))
+	if err := grpc.SetHeader(ctx, metadata.Pairs(RetryAfterMetadataKey, strconv.Itoa(retryAfter))); err != nil {
+		log.ContextLogger(ctx).Warnw("error setting retry-after header", "error", err)
+	}
+	log.ContextLogger(ctx).Errorw(message, "code", codes.ResourceExhausted, "identity", name, "scope", scope, "retryAfter", retryAfter)
+
+	st := errorStatus(ctx, codes.ResourceExhausted, reason, fmt.Errorf("%s rate limit exceeded", scope), message)
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(time.Duration(retryAfter) * time.Second)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+type authenticatedKey struct{}
+
+type authenticated struct {
+	token     string
+	principal identity.Principal
+}
+
+// withAuthenticated records that token has been verified and belongs to principal.
+func withAuthenticated(ctx context.Context, token string, principal identity.Principal) context.Context {
+	return context.WithValue(ctx, authenticatedKey{}, authenticated{token: token, principal: principal})
+}
+
+// authenticatedFromContext returns the principal of token if it has already
+// been verified.
+func authenticatedFromContext(ctx context.Context, token string) (identity.Principal, bool) {
+	a, ok := ctx.Value(authenticatedKey{}).(authenticated)
+	if !ok || a.token != token {
+		return nil, false
+	}
+	return a.principal, true
+}
@@ -0,0 +1,250 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4/jwt"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/sigstore/fulcio/pkg/audit"
+	"github.com/sigstore/fulcio/pkg/config"
+	"github.com/sigstore/fulcio/pkg/generated/protobuf"
+	"github.com/sigstore/fulcio/pkg/identity"
+)
+
+func TestRateLimiter(t *testing.T) {
+	emailSigner, emailIssuer := newOIDCIssuer(t)
+
+	// Allow each identity a burst of two certificates, and the issuer three
+	cfg, err := config.Read([]byte(fmt.Sprintf(`{
+		"OIDCIssuers": {
+			%q: {
+				"IssuerURL": %q,
+				"ClientID": "sigstore",
+				"Type": "email",
+				"RateLimit": {
+					"IdentityPerMinute": 1,
+					"IdentityBurst": 2,
+					"IssuerPerMinute": 1,
+					"IssuerBurst": 3
+				}
+			}
+		}
+	}`, emailIssuer, emailIssuer)))
+	if err != nil {
+		t.Fatalf("config.Read() = %v", err)
+	}
+
+	ctClient, eca := createCA(cfg, t)
+	ip := NewIssuerPool(cfg)
+	listener := bufconn.Listen(bufSize)
+	var buf bytes.Buffer
+	limiter := NewRateLimiter(ip, WithRateLimiterAuditLogger(audit.NewLogger("ephemeralca", audit.NewWriterSink(&buf))))
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(passFulcioConfigThruContext(cfg), limiter.UnaryServerInterceptor()))
+	protobuf.RegisterCAServer(s, NewGRPCCAServer(eca, ip, withCTLog(ctClient)))
+	go func() {
+		if err := s.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			t.Errorf("Server exited with error: %v", err)
+		}
+	}()
+	defer s.Stop()
+
+	conn, err := grpc.NewClient("passthrough",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.NewClient() = %v", err)
+	}
+	defer conn.Close()
+	client := protobuf.NewCAClient(conn)
+
+	request := func(subject string, header *metadata.MD) error {
+		t.Helper()
+		tok, err := jwt.Signed(emailSigner).Claims(jwt.Claims{
+			Issuer:   emailIssuer,
+			IssuedAt: jwt.NewNumericDate(time.Now()),
+			Expiry:   jwt.NewNumericDate(time.Now().Add(30 * time.Minute)),
+			Subject:  subject,
+			Audience: jwt.Audience{"sigstore"},
+		}).Claims(customClaims{Email: subject, EmailVerified: true}).Serialize()
+		if err != nil {
+			t.Fatalf("Serialize() = %v", err)
+		}
+		pubBytes, proof := generateKeyAndProof(subject, t)
+		_, err = client.CreateSigningCertificate(context.Background(), &protobuf.CreateSigningCertificateRequest{
+			Credentials: &protobuf.Credentials{
+				Credentials: &protobuf.Credentials_OidcIdentityToken{
+					OidcIdentityToken: tok,
+				},
+			},
+			Key: &protobuf.CreateSigningCertificateRequest_PublicKeyRequest{
+				PublicKeyRequest: &protobuf.PublicKeyRequest{
+					PublicKey: &protobuf.PublicKey{
+						Content: pubBytes,
+					},
+					ProofOfPossession: proof,
+				},
+			},
+		}, grpc.Header(header))
+		return err
+	}
+
+	var header metadata.MD
+	for i := 0; i < 2; i++ {
+		if err := request("foo@example.com", &header); err != nil {
+			t.Fatalf("request %d: unexpected error: %v", i, err)
+		}
+	}
+
+	// The identity's burst is exhausted
+	err = request("foo@example.com", &header)
+	if status.Code(err) != codes.ResourceExhausted || status.Convert(err).Message() != identityRateLimitExceeded {
+		t.Fatalf("expected identity rate limit to be exceeded, got %v", err)
+	}
+	if vals := header.Get(RetryAfterMetadataKey); len(vals) != 1 || vals[0] != "60" {
+		t.Errorf("expected retry after 60 seconds, got %v", vals)
+	}
+	var retryInfo *errdetails.RetryInfo
+	for _, detail := range status.Convert(err).Details() {
+		if ri, ok := detail.(*errdetails.RetryInfo); ok {
+			retryInfo = ri
+		}
+	}
+	if retryInfo == nil || retryInfo.RetryDelay.AsDuration() != time.Minute {
+		t.Errorf("expected retry info with a one minute delay, got %v", retryInfo)
+	}
+
+	// Rejected requests are audited, since they never reach the handler
+	var event audit.Event
+	if err := json.NewDecoder(&buf).Decode(&event); err != nil {
+		t.Fatalf("decoding audit event: %v", err)
+	}
+	if event.Outcome != audit.OutcomeFailure || event.Code != codes.ResourceExhausted.String() || event.Reason != ReasonIdentityRateLimited || event.Principal != "foo@example.com" || event.Issuer != emailIssuer {
+		t.Errorf("got audit event %+v for the rate limited request", event)
+	}
+
+	// Another identity is limited separately, until the issuer's burst is exhausted
+	if err := request("bar@example.com", &header); err != nil {
+		t.Fatalf("unexpected error for another identity: %v", err)
+	}
+	err = request("baz@example.com", &header)
+	if status.Code(err) != codes.ResourceExhausted || status.Convert(err).Message() != issuerRateLimitExceeded {
+		t.Fatalf("expected issuer rate limit to be exceeded, got %v", err)
+	}
+
+	// Requests that can't be authenticated are rejected by the handler
+	_, err = client.CreateSigningCertificate(context.Background(), &protobuf.CreateSigningCertificateRequest{
+		Credentials: &protobuf.Credentials{
+			Credentials: &protobuf.Credentials_OidcIdentityToken{
+				OidcIdentityToken: "not.a.token",
+			},
+		},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected invalid argument for invalid token, got %v", err)
+	}
+}
+
+// Tests that a rate limited request doesn't use up a single-use token, so
+// that it can be retried after the time the client was asked to wait.
+func TestRateLimiterWithSingleUseToken(t *testing.T) {
+	emailSigner, emailIssuer := newOIDCIssuer(t)
+
+	cfg, err := config.Read([]byte(fmt.Sprintf(`{
+		"OIDCIssuers": {
+			%q: {
+				"IssuerURL": %q,
+				"ClientID": "sigstore",
+				"Type": "email",
+				"SingleUseTokens": true,
+				"RateLimit": {
+					"IdentityPerMinute": 60,
+					"IdentityBurst": 1
+				}
+			}
+		}
+	}`, emailIssuer, emailIssuer)))
+	if err != nil {
+		t.Fatalf("config.Read() = %v", err)
+	}
+	store, err := identity.NewMemoryReplayStore(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctClient, eca := createCA(cfg, t)
+	ip := identity.PreventReplay(NewIssuerPool(cfg), store)
+	limiter := NewRateLimiter(ip).UnaryServerInterceptor()
+	g := NewGRPCCAServer(eca, ip, withCTLog(ctClient))
+	ctx := config.With(context.Background(), cfg)
+	info := &grpc.UnaryServerInfo{FullMethod: protobuf.CA_CreateSigningCertificate_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.CreateSigningCertificate(ctx, req.(*protobuf.CreateSigningCertificateRequest))
+	}
+
+	// Tokens with the same claims issued in the same second are identical
+	signingRequest := func(jti string) *protobuf.CreateSigningCertificateRequest {
+		t.Helper()
+		tok, err := jwt.Signed(emailSigner).Claims(jwt.Claims{
+			Issuer:   emailIssuer,
+			IssuedAt: jwt.NewNumericDate(time.Now()),
+			Expiry:   jwt.NewNumericDate(time.Now().Add(30 * time.Minute)),
+			Subject:  "foo@example.com",
+			Audience: jwt.Audience{"sigstore"},
+			ID:       jti,
+		}).Claims(customClaims{Email: "foo@example.com", EmailVerified: true}).Serialize()
+		if err != nil {
+			t.Fatalf("Serialize() = %v", err)
+		}
+		request := emailSigningRequest(t, emailSigner, emailIssuer)
+		request.Credentials.Credentials = &protobuf.Credentials_OidcIdentityToken{OidcIdentityToken: tok}
+		return request
+	}
+
+	if _, err := limiter(ctx, signingRequest("1"), info, handler); err != nil {
+		t.Fatalf("CreateSigningCertificate() = %v", err)
+	}
+	request := signingRequest("2")
+	_, err = limiter(ctx, request, info, handler)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected identity rate limit to be exceeded, got %v", err)
+	}
+
+	// One certificate is allowed per second
+	time.Sleep(1100 * time.Millisecond)
+	if _, err := limiter(ctx, request, info, handler); err != nil {
+		t.Fatalf("retrying after rate limit: CreateSigningCertificate() = %v", err)
+	}
+	time.Sleep(1100 * time.Millisecond)
+	_, err = limiter(ctx, request, info, handler)
+	if status.Code(err) != codes.InvalidArgument || status.Convert(err).Message() != identityTokenReplayed {
+		t.Fatalf("expected token to be used up, got %v", err)
+	}
+}
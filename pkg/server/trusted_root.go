@@ -0,0 +1,205 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package server
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	protocommon "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	prototrustroot "github.com/sigstore/protobuf-specs/gen/pb-go/trustroot/v1"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/sigstore/fulcio/pkg/ctl"
+	fulciogrpc "github.com/sigstore/fulcio/pkg/generated/protobuf"
+)
+
+// TrustedRootMediaType is the media type of the TrustedRoot documents served
+// by GetTrustedRoot.
+const TrustedRootMediaType = "application/vnd.dev.sigstore.trustedroot+json;version=0.1"
+
+// GetTrustedRoot returns a TrustedRoot JSON document built from the current
+// trust bundle of the CA and the public keys of the CT logs, so that clients
+// don't need to maintain one that matches this instance.
+func (g *grpcaCAServer) GetTrustedRoot(ctx context.Context, _ *fulciogrpc.GetTrustedRootRequest) (*httpbody.HttpBody, error) {
+	trustBundle, err := g.ca.TrustBundle(ctx)
+	if err != nil {
+		return nil, handleFulcioGRPCError(ctx, codes.Internal, ReasonCAError, err, retrieveTrustBundleCAError)
+	}
+
+	root := &prototrustroot.TrustedRoot{MediaType: TrustedRootMediaType}
+	for _, chain := range trustBundle {
+		if len(chain) == 0 {
+			continue
+		}
+		root.CertificateAuthorities = append(root.CertificateAuthorities, trustedCA(chain))
+	}
+	if g.logs != nil {
+		for _, member := range g.logs.Members() {
+			// Verifiers compare the validity of a log key with the SCT
+			// timestamp, the issuance time, while a shard window bounds
+			// certificate expiry. A certificate expiring in a window may have
+			// been issued any time before it, so only the end is bounded.
+			if sharded := shardedLog(member); sharded != nil {
+				for _, shard := range sharded.Shards() {
+					validFor := &protocommon.TimeRange{
+						End: timestamppb.New(shard.NotAfterLimit),
+					}
+					if err := appendCTLogs(root, shard.Log, validFor); err != nil {
+						return nil, handleFulcioGRPCError(ctx, codes.Internal, ReasonInternal, err, marshalingTrustedRootError)
+					}
+				}
+				continue
+			}
+			if err := appendCTLogs(root, member, nil); err != nil {
+				return nil, handleFulcioGRPCError(ctx, codes.Internal, ReasonInternal, err, marshalingTrustedRootError)
+			}
+		}
+	}
+
+	data, err := protojson.Marshal(root)
+	if err != nil {
+		return nil, handleFulcioGRPCError(ctx, codes.Internal, ReasonInternal, err, marshalingTrustedRootError)
+	}
+	return &httpbody.HttpBody{
+		ContentType: "application/json",
+		Data:        data,
+	}, nil
+}
+
+// shardedLog returns l, or the log wrapped by it, if it is a sharded log.
+func shardedLog(l ctl.Log) *ctl.ShardedLog {
+	switch l := l.(type) {
+	case *ctl.ShardedLog:
+		return l
+	case *ctl.RetryingLog:
+		return shardedLog(l.Log)
+	case *ctl.VerifyingLog:
+		return shardedLog(l.Log)
+	}
+	return nil
+}
+
+// appendCTLogs adds the clients of l with a configured public key to root,
+// valid for the given range if it is set.
+func appendCTLogs(root *prototrustroot.TrustedRoot, l ctl.Log, validFor *protocommon.TimeRange) error {
+	for _, client := range ctl.LogClients(l) {
+		// Only logs with a configured public key can be verified against
+		if client.Verifier == nil {
+			continue
+		}
+		ctlog, err := trustedCTLog(client.BaseURI(), client.Verifier.PubKey)
+		if err != nil {
+			return err
+		}
+		ctlog.PublicKey.ValidFor = validFor
+		root.Ctlogs = append(root.Ctlogs, ctlog)
+	}
+	return nil
+}
+
+// trustedCA describes a chain from the trust bundle, which is valid while
+// every certificate in it is valid.
+func trustedCA(chain []*x509.Certificate) *prototrustroot.CertificateAuthority {
+	root := chain[len(chain)-1]
+	ca := &prototrustroot.CertificateAuthority{
+		Subject: &protocommon.DistinguishedName{
+			CommonName: root.Subject.CommonName,
+		},
+		CertChain: &protocommon.X509CertificateChain{},
+	}
+	if len(root.Subject.Organization) > 0 {
+		ca.Subject.Organization = root.Subject.Organization[0]
+	}
+
+	var start, end time.Time
+	for _, cert := range chain {
+		ca.CertChain.Certificates = append(ca.CertChain.Certificates, &protocommon.X509Certificate{RawBytes: cert.Raw})
+		if start.IsZero() || cert.NotBefore.After(start) {
+			start = cert.NotBefore
+		}
+		if end.IsZero() || cert.NotAfter.Before(end) {
+			end = cert.NotAfter
+		}
+	}
+	ca.ValidFor = &protocommon.TimeRange{
+		Start: timestamppb.New(start),
+		End:   timestamppb.New(end),
+	}
+	return ca
+}
+
+// trustedCTLog describes the CT log at baseURL with the given public key. The
+// log ID is the SHA-256 digest of the key, as defined by RFC 6962.
+func trustedCTLog(baseURL string, pubKey crypto.PublicKey) (*prototrustroot.TransparencyLogInstance, error) {
+	der, err := x509.MarshalPKIXPublicKey(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling CT log public key: %w", err)
+	}
+	details, err := publicKeyDetails(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	logID := sha256.Sum256(der)
+	return &prototrustroot.TransparencyLogInstance{
+		BaseUrl:       baseURL,
+		HashAlgorithm: protocommon.HashAlgorithm_SHA2_256,
+		PublicKey: &protocommon.PublicKey{
+			RawBytes:   der,
+			KeyDetails: details,
+		},
+		LogId: &protocommon.LogId{KeyId: logID[:]},
+	}, nil
+}
+
+// publicKeyDetails returns the signature algorithm used with a CT log key.
+func publicKeyDetails(pubKey crypto.PublicKey) (protocommon.PublicKeyDetails, error) {
+	switch pubKey := pubKey.(type) {
+	case *ecdsa.PublicKey:
+		switch pubKey.Curve {
+		case elliptic.P256():
+			return protocommon.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256, nil
+		case elliptic.P384():
+			return protocommon.PublicKeyDetails_PKIX_ECDSA_P384_SHA_384, nil
+		case elliptic.P521():
+			return protocommon.PublicKeyDetails_PKIX_ECDSA_P521_SHA_512, nil
+		}
+	case *rsa.PublicKey:
+		switch pubKey.Size() * 8 {
+		case 2048:
+			return protocommon.PublicKeyDetails_PKIX_RSA_PKCS1V15_2048_SHA256, nil
+		case 3072:
+			return protocommon.PublicKeyDetails_PKIX_RSA_PKCS1V15_3072_SHA256, nil
+		case 4096:
+			return protocommon.PublicKeyDetails_PKIX_RSA_PKCS1V15_4096_SHA256, nil
+		}
+	case ed25519.PublicKey:
+		return protocommon.PublicKeyDetails_PKIX_ED25519, nil
+	}
+	return protocommon.PublicKeyDetails_PUBLIC_KEY_DETAILS_UNSPECIFIED, errors.New("unsupported CT log public key type")
+}
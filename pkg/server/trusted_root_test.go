@@ -0,0 +1,209 @@
+// Copyright 2024 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"net/http"
+	"testing"
+	"time"
+
+	ctclient "github.com/google/certificate-transparency-go/client"
+	"github.com/google/certificate-transparency-go/jsonclient"
+	protocommon "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	prototrustroot "github.com/sigstore/protobuf-specs/gen/pb-go/trustroot/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/sigstore/fulcio/pkg/ca/ephemeralca"
+	"github.com/sigstore/fulcio/pkg/config"
+	"github.com/sigstore/fulcio/pkg/ctl"
+	"github.com/sigstore/fulcio/pkg/generated/protobuf"
+)
+
+func TestGetTrustedRoot(t *testing.T) {
+	eca, err := ephemeralca.NewEphemeralCA()
+	if err != nil {
+		t.Fatalf("ephemeralca.NewEphemeralCA() = %v", err)
+	}
+	logKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logKeyDER, err := x509.MarshalPKIXPublicKey(logKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ct, err := ctclient.New("https://ctfe.example.com/test", &http.Client{Timeout: 5 * time.Second}, jsonclient.Options{PublicKeyDER: logKeyDER})
+	if err != nil {
+		t.Fatalf("ctclient.New() = %v", err)
+	}
+
+	cfg := &config.FulcioConfig{}
+	server, conn := setupGRPCForTest(t, cfg, ct, eca)
+	defer func() {
+		server.Stop()
+		conn.Close()
+	}()
+
+	body, err := protobuf.NewCAClient(conn).GetTrustedRoot(context.Background(), &protobuf.GetTrustedRootRequest{})
+	if err != nil {
+		t.Fatalf("GetTrustedRoot() = %v", err)
+	}
+	if body.ContentType != "application/json" {
+		t.Errorf("got content type %q, wanted application/json", body.ContentType)
+	}
+	var root prototrustroot.TrustedRoot
+	if err := protojson.Unmarshal(body.Data, &root); err != nil {
+		t.Fatalf("unmarshaling trusted root: %v", err)
+	}
+	if root.MediaType != TrustedRootMediaType {
+		t.Errorf("got media type %q, wanted %q", root.MediaType, TrustedRootMediaType)
+	}
+
+	if len(root.CertificateAuthorities) != 1 {
+		t.Fatalf("expected 1 certificate authority, got %d", len(root.CertificateAuthorities))
+	}
+	certs, _ := eca.GetSignerWithChain()
+	ca := root.CertificateAuthorities[0]
+	if len(ca.CertChain.Certificates) != 1 || !bytes.Equal(ca.CertChain.Certificates[0].RawBytes, certs[0].Raw) {
+		t.Error("certificate chain does not match the CA certificate")
+	}
+	if ca.Subject.CommonName != certs[0].Subject.CommonName {
+		t.Errorf("got subject %q, wanted %q", ca.Subject.CommonName, certs[0].Subject.CommonName)
+	}
+	if !ca.ValidFor.Start.AsTime().Equal(certs[0].NotBefore) || !ca.ValidFor.End.AsTime().Equal(certs[0].NotAfter) {
+		t.Errorf("got validity %v to %v, wanted %v to %v", ca.ValidFor.Start.AsTime(), ca.ValidFor.End.AsTime(), certs[0].NotBefore, certs[0].NotAfter)
+	}
+
+	if len(root.Ctlogs) != 1 {
+		t.Fatalf("expected 1 CT log, got %d", len(root.Ctlogs))
+	}
+	ctlog := root.Ctlogs[0]
+	if ctlog.BaseUrl != "https://ctfe.example.com/test" {
+		t.Errorf("got base URL %q", ctlog.BaseUrl)
+	}
+	if !bytes.Equal(ctlog.PublicKey.RawBytes, logKeyDER) {
+		t.Error("CT log public key does not match")
+	}
+	if ctlog.PublicKey.KeyDetails != protocommon.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256 {
+		t.Errorf("got key details %v", ctlog.PublicKey.KeyDetails)
+	}
+	logID := sha256.Sum256(logKeyDER)
+	if !bytes.Equal(ctlog.LogId.KeyId, logID[:]) {
+		t.Error("CT log ID does not match the key")
+	}
+}
+
+func TestGetTrustedRootShardedLog(t *testing.T) {
+	eca, err := ephemeralca.NewEphemeralCA()
+	if err != nil {
+		t.Fatalf("ephemeralca.NewEphemeralCA() = %v", err)
+	}
+	windows := []time.Time{
+		time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2028, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	var shards []ctl.Shard
+	for i, url := range []string{"https://ctfe.example.com/2026", "https://ctfe.example.com/2027"} {
+		logKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		logKeyDER, err := x509.MarshalPKIXPublicKey(logKey.Public())
+		if err != nil {
+			t.Fatal(err)
+		}
+		ct, err := ctclient.New(url, &http.Client{Timeout: 5 * time.Second}, jsonclient.Options{PublicKeyDER: logKeyDER})
+		if err != nil {
+			t.Fatalf("ctclient.New() = %v", err)
+		}
+		shards = append(shards, ctl.Shard{Log: ct, NotAfterStart: windows[i], NotAfterLimit: windows[i+1]})
+	}
+	sharded, err := ctl.NewShardedLog(shards...)
+	if err != nil {
+		t.Fatalf("NewShardedLog() = %v", err)
+	}
+	logs, err := ctl.NewLogs(1, ctl.NewRetryingLog("sharded", sharded))
+	if err != nil {
+		t.Fatalf("NewLogs() = %v", err)
+	}
+
+	g := NewGRPCCAServer(eca, nil, WithCTLogs(logs))
+	body, err := g.GetTrustedRoot(context.Background(), &protobuf.GetTrustedRootRequest{})
+	if err != nil {
+		t.Fatalf("GetTrustedRoot() = %v", err)
+	}
+	var root prototrustroot.TrustedRoot
+	if err := protojson.Unmarshal(body.Data, &root); err != nil {
+		t.Fatalf("unmarshaling trusted root: %v", err)
+	}
+	if len(root.Ctlogs) != 2 {
+		t.Fatalf("expected 2 CT log shards, got %d", len(root.Ctlogs))
+	}
+	for i, ctlog := range root.Ctlogs {
+		validFor := ctlog.PublicKey.ValidFor
+		// SCTs are timestamped at issuance, before the window, so the start is open
+		if validFor == nil || validFor.Start != nil || !validFor.End.AsTime().Equal(windows[i+1]) {
+			t.Errorf("shard %s: got validity %v, wanted until %v", ctlog.BaseUrl, validFor, windows[i+1])
+		}
+	}
+}
+
+func TestGetTrustedRootWithoutCTLogKey(t *testing.T) {
+	cfg := &config.FulcioConfig{}
+	ct, eca := createCA(cfg, t)
+	server, conn := setupGRPCForTest(t, cfg, ct, eca)
+	defer func() {
+		server.Stop()
+		conn.Close()
+	}()
+
+	body, err := protobuf.NewCAClient(conn).GetTrustedRoot(context.Background(), &protobuf.GetTrustedRootRequest{})
+	if err != nil {
+		t.Fatalf("GetTrustedRoot() = %v", err)
+	}
+	var root prototrustroot.TrustedRoot
+	if err := protojson.Unmarshal(body.Data, &root); err != nil {
+		t.Fatalf("unmarshaling trusted root: %v", err)
+	}
+	if len(root.CertificateAuthorities) != 1 {
+		t.Errorf("expected 1 certificate authority, got %d", len(root.CertificateAuthorities))
+	}
+	if len(root.Ctlogs) != 0 {
+		t.Errorf("expected no CT logs without a CT log public key, got %d", len(root.Ctlogs))
+	}
+}
+
+func TestGetTrustedRootFailingCA(t *testing.T) {
+	server, conn := setupGRPCForTest(t, &config.FulcioConfig{}, nil, &FailingCertificateAuthority{})
+	defer func() {
+		server.Stop()
+		conn.Close()
+	}()
+
+	_, err := protobuf.NewCAClient(conn).GetTrustedRoot(context.Background(), &protobuf.GetTrustedRootRequest{})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("expected Internal error, got %v", err)
+	}
+}